@@ -0,0 +1,380 @@
+// Functions within this file implement the non-interactive subcommands,
+// which print their output to stdout instead of starting the console.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Zedran/myrtle/orbit"
+	"github.com/Zedran/myrtle/source"
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Describes the subcommands in the usage message.
+const COMMANDS_USAGE string = `Commands:
+  query     list the objects matching the query
+  elements  print the orbital elements of an object
+  passes    print the passes of an object over the observer
+
+Run 'myrtle command -h' for the options of the command.
+`
+
+// The error returned from the subcommands if the query matched no objects.
+var errNoMatches = errors.New("no objects found")
+
+// Settings shared by the subcommands.
+type cli struct {
+	// Source of the element sets
+	src source.Source
+
+	// Output of the subcommands
+	out io.Writer
+}
+
+// Query options shared by the subcommands. Exactly one query value
+// must be given.
+type queryFlags struct {
+	values map[source.QueryKind]*string
+
+	// Number of the result the object is picked from, starting at 1
+	index int
+}
+
+// Registers the query options in the flag set. If pick is true,
+// the option choosing one of the results is registered as well.
+func newQueryFlags(fs *flag.FlagSet, pick bool) *queryFlags {
+	qf := queryFlags{values: make(map[source.QueryKind]*string)}
+
+	qf.values[source.QUERY_NAME] = fs.String("name", "", "object name or its part")
+	qf.values[source.QUERY_CATNR] = fs.String("catnr", "", "NORAD catalog number")
+	qf.values[source.QUERY_INTDES] = fs.String("intdes", "", "international designator, e.g. 1998-067A")
+	qf.values[source.QUERY_GROUP] = fs.String("group", "", "group of objects, e.g. stations")
+	qf.values[source.QUERY_SPECIAL] = fs.String("special", "", "special dataset, e.g. gpz")
+
+	if pick {
+		fs.IntVar(&qf.index, "index", 1, "number of the result if the query matches more than one object")
+	}
+
+	return &qf
+}
+
+// Returns true if none of the query values is given.
+func (qf *queryFlags) empty() bool {
+	for _, v := range qf.values {
+		if len(*v) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the kind and the value of the query.
+func (qf *queryFlags) query() (source.QueryKind, string, error) {
+	var (
+		kind  source.QueryKind
+		value string
+		n     int
+	)
+
+	for k, v := range qf.values {
+		if len(*v) > 0 {
+			kind, value = k, *v
+			n++
+		}
+	}
+
+	if n != 1 {
+		return 0, "", errors.New("expected exactly one of: --name, --catnr, --intdes, --group, --special")
+	}
+
+	return kind, value, nil
+}
+
+// Runs the subcommand given as the first argument. The remaining arguments
+// are the options of the subcommand.
+func (c *cli) run(args []string) error {
+	commands := map[string]func([]string) error{
+		"query":    c.query,
+		"elements": c.elements,
+		"passes":   c.passes,
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+
+	return cmd(args[1:])
+}
+
+// Requests the objects matching the query.
+func (c *cli) fetch(qf *queryFlags) ([]*tle.Match, error) {
+	kind, value, err := qf.query()
+	if err != nil {
+		return nil, err
+	}
+
+	matches, _, err := c.src.Query(kind, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, errNoMatches
+	}
+
+	return matches, nil
+}
+
+// Requests the objects matching the query and parses the one picked
+// by the index option.
+func (c *cli) fetchOne(qf *queryFlags) (*tle.TLE, error) {
+	matches, err := c.fetch(qf)
+	if err != nil {
+		return nil, err
+	}
+
+	if qf.index < 1 || qf.index > len(matches) {
+		return nil, fmt.Errorf("index out of range <1;%d>: %d", len(matches), qf.index)
+	}
+
+	return tle.ParseMatch(matches[qf.index-1])
+}
+
+// Lists the objects matching the query: the number of the result,
+// the catalog number and the name of the object.
+func (c *cli) query(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	qf := newQueryFlags(fs, false)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	matches, err := c.fetch(qf)
+	if err != nil {
+		return err
+	}
+
+	for i, m := range matches {
+		fmt.Fprintf(c.out, "%4d  %-9s  %s\n", i+1, m.GetCatNum(), strings.TrimSpace(m.Title))
+	}
+
+	return nil
+}
+
+// Prints the object page of the object: the orbital elements and the values
+// derived from its propagated state. Alternatively, the elements are written
+// in JSON or CSV format. Instead of the query, the state vector
+// of the object may be given.
+func (c *cli) elements(args []string) error {
+	fs := flag.NewFlagSet("elements", flag.ContinueOnError)
+	qf := newQueryFlags(fs, true)
+
+	alt := fs.Bool("altitude", false, "display altitudes above sea level instead of radii")
+	acc := fs.Bool("precise", false, "display precise values")
+	kozai := fs.Bool("kozai", false, "display the values derived from the Kozai mean motion alongside")
+	state := fs.Bool("state", false, "display the state vectors below the elements")
+	at := fs.String("time", "", "time of the elements, the same as accepted by /t command, e.g. now or '+90m'")
+	obsCoords := fs.String("observer", "", "observer location: latitude,longitude[,height]")
+	output := fs.String("output", "text", "output format: text, json or csv")
+	bodyName := fs.String("body", "earth", "dominant body: earth, moon, mars or sun")
+	constsName := fs.String("constants", "wgs72", "constants of Earth: wgs72, wgs84 or egm96")
+	rv := fs.String("rv", "", "state vector instead of the query: x,y,z,vx,vy,vz in the inertial frame [m, m/s]")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*rv) > 0 && !qf.empty() {
+		return errors.New("--rv cannot be combined with a query")
+	}
+
+	if *output != "text" && *output != "json" && *output != "csv" {
+		return fmt.Errorf("unknown output format: %s", *output)
+	}
+
+	body, err := orbit.FindBody(*bodyName)
+	if err != nil {
+		return err
+	}
+
+	consts, err := orbit.FindConstants(*constsName)
+	if err != nil {
+		return err
+	}
+
+	var obs *orbit.Observer
+
+	if len(*obsCoords) > 0 {
+		if obs, err = ParseObserver(strings.Split(*obsCoords, ",")); err != nil {
+			return err
+		}
+	}
+
+	var (
+		set *tle.TLE
+		obj *orbit.Elements
+	)
+
+	if len(*rv) > 0 {
+		set, obj, err = stateElements(*rv, *at, withConstants(body, consts))
+	} else {
+		set, obj, err = c.queryElements(qf, *at, withConstants(body, consts))
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output != "text" {
+		return writeElements(c.out, obj, *output)
+	}
+
+	// SGP4 models the orbits around Earth only
+	var prop *orbit.SGP4
+
+	if body == orbit.Earth {
+		if prop, err = orbit.NewSGP4(set); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(c.out, GetTitle(obj))
+
+	for _, line := range objectLines(obj, prop, obs, *alt, *acc, *kozai) {
+		fmt.Fprintln(c.out, " ", line)
+	}
+
+	if *state {
+		fmt.Fprintln(c.out)
+		for _, line := range stateLines(obj, *acc) {
+			fmt.Fprintln(c.out, " ", line)
+		}
+	}
+
+	return nil
+}
+
+// Requests the object matching the query and calculates its elements
+// around the body, at the time given by the time option relative
+// to the epoch.
+func (c *cli) queryElements(qf *queryFlags, at string, b *orbit.Body) (*tle.TLE, *orbit.Elements, error) {
+	set, err := c.fetchOne(qf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	obj := orbit.CalculateElementsAround(set, b)
+
+	if len(at) > 0 {
+		t, err := parseTime(at, time.Unix(obj.Epoch, 0))
+		if err != nil {
+			return nil, nil, err
+		}
+		obj = obj.AdvanceTo(t.Unix())
+	}
+
+	return set, obj, nil
+}
+
+// Creates the elements from the comma-separated state vector, at the time
+// given by the time option relative to the current time, and encodes them
+// as a set for the propagation.
+func stateElements(rv, at string, b *orbit.Body) (*tle.TLE, *orbit.Elements, error) {
+	t := time.Now()
+
+	if len(at) > 0 {
+		var err error
+		if t, err = parseTime(at, t); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	obj, err := ParseState(strings.Split(rv, ","), t, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set, err := StateToTLE(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return set, obj, nil
+}
+
+// Prints the passes of the object above the observer's horizon.
+func (c *cli) passes(args []string) error {
+	fs := flag.NewFlagSet("passes", flag.ContinueOnError)
+	qf := newQueryFlags(fs, true)
+
+	obsCoords := fs.String("observer", "", "observer location: latitude,longitude[,height] (required)")
+	from := fs.String("start", "now", "start of the search, the same as accepted by /t command")
+	window := fs.String("window", "1d", "length of the search window, e.g. 12h or 3d")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*obsCoords) == 0 {
+		return errors.New("observer location is not set, use --observer")
+	}
+
+	obs, err := ParseObserver(strings.Split(*obsCoords, ","))
+	if err != nil {
+		return err
+	}
+
+	start, err := parseTime(*from, time.Now())
+	if err != nil {
+		return err
+	}
+
+	d, err := ParseDuration(*window)
+	if err != nil {
+		return err
+	}
+
+	set, err := c.fetchOne(qf)
+	if err != nil {
+		return err
+	}
+
+	passes, err := orbit.PredictPasses(set, obs, start, start.Add(d))
+
+	for _, line := range passLines(passes) {
+		fmt.Fprintln(c.out, line)
+	}
+
+	return err
+}
+
+// Parses the time given in the notation of the time command.
+// The relative values are measured from ref.
+func parseTime(s string, ref time.Time) (time.Time, error) {
+	clock := NewClock(ref, 0)
+
+	if err := clock.Parse(strings.Fields(s)); err != nil {
+		return time.Time{}, err
+	}
+
+	return clock.Now(), nil
+}
+
+// Writes the elements in the specified format: json or csv.
+func writeElements(w io.Writer, obj *orbit.Elements, format string) error {
+	switch format {
+	case "json":
+		return orbit.WriteElementsJSON(w, []*orbit.Elements{obj})
+	case "csv":
+		return orbit.WriteElementsCSV(w, []*orbit.Elements{obj})
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Zedran/myrtle/source"
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Response of the stub server: two sets matching the name "ISS"
+const sample = "ISS (ZARYA)             \r\n" +
+	"1 25544U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  9991\r\n" +
+	"2 25544  51.6452  19.1428 0006828  17.5887  10.3753 15.49476744321309\r\n" +
+	"SWISSCUBE               \r\n" +
+	"1 35932U 09051B   22013.55765441  .00000268  00000+0  71136-4 0  9999\r\n" +
+	"2 35932  98.5837 225.0161 0007892 155.7494 204.4076 14.56655971653547\r\n"
+
+// Serves the same body in response to every request
+// and records the requested URLs.
+type stubTransport struct {
+	body     string
+	requests []*url.URL
+}
+
+func (st *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	st.requests = append(st.requests, req.URL)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(bytes.NewBufferString(st.body)),
+		Request:    req,
+	}, nil
+}
+
+// Tests the subcommands. The output must contain the expected lines,
+// and the invalid options must be reported as errors.
+func TestCLI(t *testing.T) {
+	cases := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"query", "--name", "ISS"}, []string{"   1  25544U     ISS (ZARYA)", "   2  35932U     SWISSCUBE"}},
+		{[]string{"elements", "--name", "ISS", "--index", "2"}, []string{"SWISSCUBE", "Ecc  0.0008"}},
+		{[]string{"elements", "--catnr", "25544", "--time", "+1h"}, []string{"+0d 01:00:00 since epoch"}},
+		{[]string{"elements", "--name", "ISS", "--output", "json"}, []string{`"semi_major_axis_m": 6796897.55`, `"kozai_semi_major_axis_m": 6796394.76`, `"line2": "2 25544  51.6452`}},
+		{[]string{"elements", "--name", "ISS", "--output", "csv"}, []string{"name,line1,line2,epoch,time,", "ISS (ZARYA),1 25544U"}},
+		{[]string{"elements", "--name", "ISS", "--body", "moon"}, []string{"ISS (ZARYA)    MOON"}},
+		{[]string{"elements", "--name", "ISS", "--constants", "egm96", "--altitude"}, []string{"EARTH EGM-96", "PeA  415.3k"}},
+		{[]string{"elements", "--name", "ISS", "--kozai"}, []string{"KOZAI:", "SMa  6.796M"}},
+		{[]string{"elements", "--rv", "6778k,0,0,0,4.8k,5.9k", "--time", "2026-10-16T12:00:00Z"}, []string{"STATE VECTOR    EARTH WGS-72", "1 00000U          26289.50000000", "Inc   50.87°", "Lat   -0.08°"}},
+		{[]string{"elements", "--name", "ISS", "--state"}, []string{"ECI r           5.018M          3.835M          2.499M"}},
+		{[]string{"passes", "--name", "ISS", "--observer", "52.2,21.0", "--start", "2022-01-14T12:00:00Z"}, []string{"AOS", "2022-01-14 22:0"}},
+	}
+
+	for _, tc := range cases {
+		var out bytes.Buffer
+
+		c := cli{
+			src: source.NewHTTPSource(&http.Client{Transport: &stubTransport{body: sample}}, source.CELESTRAK_URL, nil, tle.FORMAT_TLE),
+			out: &out,
+		}
+
+		if err := c.run(tc.args); err != nil {
+			t.Fatalf("%v: %v", tc.args, err)
+		}
+
+		for _, w := range tc.want {
+			if !strings.Contains(out.String(), w) {
+				t.Errorf("%v: %q not found in output:\n%s", tc.args, w, out.String())
+			}
+		}
+	}
+
+	invalid := [][]string{
+		{"unknown"},
+		{"query"},
+		{"query", "--name", "ISS", "--catnr", "25544"},
+		{"elements", "--name", "ISS", "--index", "3"},
+		{"elements", "--name", "ISS", "--output", "xml"},
+		{"elements", "--name", "ISS", "--body", "vulcan"},
+		{"elements", "--name", "ISS", "--constants", "wgs60"},
+		{"elements", "--name", "ISS", "--rv", "6778k,0,0,0,7.6k,0"},
+		{"elements", "--rv", "6778k,0,0,0,7.6k"},
+		{"elements", "--rv", "6778k,0,0,0,11k,0"},
+		{"passes", "--name", "ISS"},
+		{"passes", "--name", "ISS", "--observer", "91,0"},
+	}
+
+	for _, args := range invalid {
+		c := cli{
+			src: source.NewHTTPSource(&http.Client{Transport: &stubTransport{body: sample}}, source.CELESTRAK_URL, nil, tle.FORMAT_TLE),
+			out: io.Discard,
+		}
+
+		if err := c.run(args); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+// Tests the query by a name containing spaces and special characters.
+// The name must reach the server unchanged.
+func TestCLINameQuery(t *testing.T) {
+	const name = "ISS (ZARYA) & #1"
+
+	st := &stubTransport{body: sample}
+
+	c := cli{
+		src: source.NewHTTPSource(&http.Client{Transport: st}, source.CELESTRAK_URL, nil, tle.FORMAT_TLE),
+		out: io.Discard,
+	}
+
+	if err := c.run([]string{"query", "--name", name}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(st.requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(st.requests))
+	}
+
+	if got := st.requests[0].Query().Get("NAME"); got != name {
+		t.Fatalf("Server received name %q, expected %q (%s)", got, name, st.requests[0])
+	}
+}
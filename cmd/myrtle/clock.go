@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock keeps the simulation time of the session. Starting from the instant
+// it was last set, the simulation time flows at a rate relative to the wall
+// clock, e.g. 60 times faster or backwards.
+type Clock struct {
+	// Simulation time at the moment of the last adjustment
+	base time.Time
+
+	// Wall clock time of the last adjustment
+	set time.Time
+
+	// Speed of the simulation time relative to the wall clock
+	rate float64
+}
+
+// Creates a new clock showing time t and running at the specified rate.
+func NewClock(t time.Time, rate float64) *Clock {
+	return &Clock{base: t, set: time.Now(), rate: rate}
+}
+
+// Returns the current simulation time.
+func (c *Clock) Now() time.Time {
+	elapsed := float64(time.Since(c.set)) * c.rate
+	return c.base.Add(time.Duration(elapsed))
+}
+
+// Returns the rate at which the simulation time flows.
+func (c *Clock) Rate() float64 {
+	return c.rate
+}
+
+// Sets the simulation time to t, keeping the current rate.
+func (c *Clock) Set(t time.Time) {
+	c.base = t
+	c.set = time.Now()
+}
+
+// Changes the rate without causing a jump of the simulation time.
+func (c *Clock) SetRate(rate float64) {
+	c.Set(c.Now())
+	c.rate = rate
+}
+
+// Applies the arguments of the time command in order. Accepted arguments are:
+//
+//   - now                  - wall clock time, running at real-time rate
+//   - 2026-10-20T12:00:00Z - absolute time in RFC 3339 format
+//   - +90m, -2h, +1d12h    - offset from the current simulation time
+//   - x60, x-1, x0         - rate of the simulation time
+//
+// If any of the arguments is invalid, the clock is left unchanged.
+func (c *Clock) Parse(args []string) error {
+	next := *c
+
+	for _, arg := range args {
+		switch {
+		case arg == "now":
+			next.Set(time.Now())
+			next.rate = 1
+		case strings.HasPrefix(arg, "x"):
+			rate, err := strconv.ParseFloat(arg[1:], 64)
+			if err != nil {
+				return fmt.Errorf("invalid rate: %s", arg)
+			}
+			next.SetRate(rate)
+		case strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-"):
+			d, err := ParseDuration(arg)
+			if err != nil {
+				return err
+			}
+			next.Set(next.Now().Add(d))
+		default:
+			t, err := time.Parse(time.RFC3339, arg)
+			if err != nil {
+				return fmt.Errorf("invalid time: %s", arg)
+			}
+			next.Set(t)
+		}
+	}
+
+	*c = next
+	return nil
+}
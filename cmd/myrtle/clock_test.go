@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests Clock.Parse method. Arguments must be applied in order and an invalid
+// argument must leave the clock unchanged.
+func TestClockParse(t *testing.T) {
+	start := time.Date(2026, time.October, 20, 12, 0, 0, 0, time.UTC)
+
+	c := NewClock(start, 0)
+
+	if err := c.Parse([]string{"+90m", "-1d"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := start.Add(90*time.Minute - 24*time.Hour); !c.Now().Equal(want) || c.Rate() != 0 {
+		t.Fatalf("Got %v x%g, expected %v x0", c.Now(), c.Rate(), want)
+	}
+
+	if err := c.Parse([]string{"2030-01-01T00:00:00Z", "x2", "bogus"}); err == nil {
+		t.Fatal("Error expected for invalid argument")
+	}
+
+	if c.Rate() != 0 || c.Now().Year() != 2026 {
+		t.Fatalf("Clock modified by invalid arguments: %v x%g", c.Now(), c.Rate())
+	}
+}
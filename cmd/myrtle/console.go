@@ -0,0 +1,1298 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zedran/myrtle/orbit"
+	"github.com/Zedran/myrtle/source"
+	"github.com/Zedran/myrtle/tle"
+	"github.com/pterm/pterm"
+	"github.com/pterm/pterm/putils"
+)
+
+// Current page indicator type
+type Page uint8
+
+const (
+	EXIT Page = iota
+	START_PAGE
+	RESULTS_PAGE
+	OBJECT_PAGE
+)
+
+const (
+	LONG_DELAY  = 50 * time.Millisecond
+	MED_DELAY   = 25 * time.Millisecond
+	SHORT_DELAY = 10 * time.Millisecond
+
+	// Interval between object page refreshes while the simulation clock is set
+	REFRESH_DELAY = time.Second
+
+	RES_PER_PAGE int = 20
+
+	TERM_HEIGHT int = 26
+	TERM_WIDTH  int = 80
+)
+
+type Console struct {
+	// Old console dimensions that are restored on exit
+	oldH,
+	oldW int
+
+	// Currently displayed page
+	page Page
+
+	// Current results page
+	resPage int
+
+	// Display radius / altitude ASL
+	radius bool
+
+	// Display precise / shortened values
+	precise bool
+
+	// Display the values derived from the Kozai mean motion alongside
+	kozai bool
+
+	// Kind of the query value: name, catalog number, group etc.
+	queryKind source.QueryKind
+
+	// Source of the element sets
+	src source.Source
+
+	// If true, the data is served from cache only
+	offline bool
+
+	// If true, the current matches were read from local files
+	local bool
+
+	// Time the current matches were downloaded
+	fetched time.Time
+
+	// Input scanner
+	scanner *bufio.Scanner
+
+	// Lines read by the input scanner
+	input chan string
+
+	// Simulation clock. If nil, elements are displayed at the epoch.
+	clock *Clock
+
+	// Location of the ground station. If nil, passes are not predicted.
+	observer *orbit.Observer
+
+	// Dominant body the elements are calculated for. The propagation
+	// is available for Earth only.
+	body *orbit.Body
+
+	// Set of constants used if the dominant body is Earth
+	consts *orbit.Body
+
+	// A pointer to current phrase
+	phrase *Phrase
+
+	// A list of pointers to found matches
+	matches []*tle.Match
+
+	// TLE of the most recently computed object
+	curTLE *tle.TLE
+
+	// A pointer to most recently computed object
+	curObj *orbit.Elements
+
+	// Propagator of the most recently computed object. If nil,
+	// the values derived from the propagated state are not displayed.
+	curProp *orbit.SGP4
+
+	// The reason the propagator of the current object is not available
+	propErr error
+}
+
+// Main method of struct that launches and drives the interface.
+func (c *Console) Run() {
+	defer c.clear()
+	defer c.restoreTerminalSize()
+	c.clear()
+
+	for {
+		switch c.page {
+		case EXIT:
+			return
+		case START_PAGE:
+			c.showStartPage()
+		case RESULTS_PAGE:
+			c.showResultsPage()
+		case OBJECT_PAGE:
+			c.showObjectPage()
+		default:
+			Logf("Unknown Action value: %d", c.page)
+		}
+	}
+}
+
+// Creates a list of matches according to provided string.
+func (c *Console) fetchData(queryString string) {
+	var (
+		matches []*tle.Match
+		err     error
+	)
+	c.matches = nil
+
+	var fetched time.Time
+
+	matches, fetched, err = c.src.Query(c.queryKind, queryString)
+	if err != nil {
+		pterm.Println(err)
+		if err != source.ErrShortQuery && !errors.Is(err, source.ErrNotCached) {
+			Log(err)
+		}
+		return
+	}
+
+	if len(matches) > 0 {
+		c.matches = matches
+		c.fetched = fetched
+		c.resPage = 0
+		c.local = isLocal(c.src)
+	} else {
+		pterm.Println("No matches found.")
+	}
+}
+
+// Prints the starting page.
+func (c *Console) showStartPage() {
+	c.clear()
+
+	c.offSetBy(6)
+
+	title, err := pterm.DefaultBigText.WithLetters(
+		putils.LettersFromStringWithStyle("My", pterm.NewStyle(pterm.FgLightGreen)),
+		putils.LettersFromStringWithStyle("R", pterm.NewStyle(pterm.FgRed)),
+		putils.LettersFromStringWithStyle("TLE", pterm.NewStyle(pterm.FgCyan))).Srender()
+	if err != nil {
+		Log(err)
+	}
+
+	pterm.DefaultCenter.Print(title)
+
+	pterm.DefaultCenter.Print("My Refined TLE Browser")
+
+	c.offSetBy(5)
+	pterm.Println(" Enter the name of the object or type '/h' to read help message.")
+
+	c.offSetBy(5)
+	c.showSearchDialog()
+}
+
+// Prints the page containing the query results.
+func (c *Console) showResultsPage() {
+	c.clear()
+	c.printMatches()
+	c.offSetBy(2)
+
+	n := c.pickResult()
+
+	if n == -1 {
+		return
+	}
+
+	set, err := tle.ParseMatch(c.matches[n])
+	if err != nil {
+		pterm.Printfln("%s: %v", c.matches[n].Title, err)
+		c.getInput("Press Enter to continue...")
+		return
+	}
+
+	c.setCurrent(set)
+	c.nextPage()
+}
+
+// Makes the TLE the current object: calculates its elements around
+// the dominant body and sets up the propagator.
+func (c *Console) setCurrent(set *tle.TLE) {
+	c.curTLE = set
+	c.curObj = orbit.CalculateElementsAround(set, withConstants(c.body, c.consts))
+	c.curProp = nil
+
+	// SGP4 models the orbits around Earth only
+	if c.body != orbit.Earth {
+		c.propErr = errors.New("SGP4 models orbits around Earth only")
+		return
+	}
+
+	c.curProp, c.propErr = orbit.NewSGP4(set)
+	if c.propErr != nil {
+		Log(c.propErr)
+	}
+}
+
+// Displays the object page containing calculated orbital elements
+// for the selected sattellite.
+func (c *Console) showObjectPage() {
+	c.clear()
+	c.printElements(!c.radius, c.precise, c.kozai)
+
+	if c.clock != nil && c.clock.Rate() != 1 {
+		pterm.Printfln("  RATE x%g", c.clock.Rate())
+	} else {
+		c.offSetBy(1)
+	}
+
+	c.showSearchDialog()
+}
+
+// Displays the help pages: the list of commands, followed by the list
+// of symbols.
+func (c *Console) showHelpPage() {
+	commands := []string{
+		"Commands:\n",
+		" /b      -  back                      |  /e      -  exit",
+		" /f      -  forward                   |  /h      -  help",
+		" /a      -  display altitude          |  /r      -  display radius",
+		" /p      -  precise values            |  /s      -  short values",
+		" /k      -  Kozai values too          |  /nk     -  no Kozai values",
+		" /n      -  search by name            |  /c      -  search by catalog number",
+		" /i      -  search by intl designator |  /g      -  search by group",
+		" /sp     -  search special dataset    |  />[n]   -  next results page",
+		" /<[n]   -  previous results page     |  /l      -  current time",
+		" /ep     -  epoch time                |  /t      -  simulation time",
+		" /gt     -  ground track              |  /obs    -  observer location",
+		" /passes -  passes over observer      |  /dop    -  Doppler shift",
+		" /set    -  edit element              |  /tle    -  three-line set",
+		" /exp    -  export to JSON or CSV     |  /open   -  open TLE file",
+		" /body   -  dominant body             |  /const  -  constants of Earth",
+		" /sv     -  state vectors             |  /rv     -  object from state vector",
+		"\nReferences:\n",
+		" * CelesTrak - https://celestrak.com",
+		" * pterm     - https://github.com/pterm/pterm",
+	}
+
+	symbols := []string{
+		"Symbols:\n",
+		" SMa    -  Semi-Major Axis            |  SMi    -  Semi-Minor Axis",
+		" PeR/A  -  Periapsis Radius/Altitude  |  ApR/A  -  Apoapsis Radius/Altitude",
+		" R/Alt  -  Radius/Altitude            |  Ecc    -  Orbital Eccentricity",
+		" T      -  Orbital Period             |  PeT    -  Time to Periapsis",
+		" ApT    -  Time to Apoapsis           |  Vel    -  Orbital Velocity",
+		" Inc    -  Orbital Inclination        |  LAN    -  Longitude of Ascending Node",
+		" LPe    -  Longitude of Periapsis     |  AgP    -  Argument of Periapsis",
+		" TrA    -  True Anomaly               |  TrL    -  True Longitude",
+		" MnA    -  Mean Anomaly               |  MnL    -  Mean Longitude",
+		" EcA    -  Eccentric Anomaly          |  Hgt    -  Height above Ellipsoid",
+		" Lat    -  Sub-satellite Latitude     |  Lon    -  Sub-satellite Longitude",
+		" Az/El  -  Azimuth/Elevation          |  Rng    -  Range to Observer",
+		" RRt    -  Range Rate",
+	}
+
+	for _, msg := range [][]string{commands, symbols} {
+		c.clear()
+
+		for i := range msg {
+			pterm.Println(msg[i])
+			time.Sleep(SHORT_DELAY)
+		}
+
+		c.offSetBy(1)
+		c.getInput("Press Enter to continue...")
+	}
+}
+
+// Gets input from the user and creates a phrase from it.
+func (c *Console) getInput(prompt string) *Phrase {
+	return c.getRefreshedInput(prompt, nil)
+}
+
+// Gets input from the user and creates a phrase from it. If refresh
+// is not nil, it is called every REFRESH_DELAY while waiting for input.
+func (c *Console) getRefreshedInput(prompt string, refresh func()) *Phrase {
+	pterm.Print(prompt + "  ")
+
+	var tick <-chan time.Time
+
+	if refresh != nil {
+		ticker := time.NewTicker(REFRESH_DELAY)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case text, ok := <-c.input:
+			if !ok {
+				return NewPhrase("/e")
+			}
+			return NewPhrase(text)
+		case <-tick:
+			refresh()
+		}
+	}
+}
+
+// Reads the input lines and passes them to the input channel.
+// The channel is closed when the input ends.
+func (c *Console) readInput() {
+	for c.scanner.Scan() {
+		c.input <- c.scanner.Text()
+	}
+	close(c.input)
+}
+
+// Prompts the user to pick the result and runs commands included inside
+// the input.
+func (c *Console) pickResult() int {
+	length := len(c.matches)
+
+	for {
+		phrase := c.getInput("PICK RESULT:")
+
+		if phrase == nil {
+			continue
+		}
+
+		if !c.runCommands(phrase) {
+			return -1
+		}
+
+		n, err := strconv.Atoi(phrase.Object)
+
+		if err != nil {
+			pterm.Println("Not a number")
+			continue
+		} else if n <= 0 || n > length {
+			pterm.Printfln("Number not in range of the matches pool (1;%d>", length)
+			continue
+		}
+
+		return n - 1
+	}
+}
+
+// Displays the search dialog and launches action depending
+// on the provided input.
+func (c *Console) showSearchDialog() {
+	var refresh func()
+
+	if c.page == OBJECT_PAGE && c.clock != nil {
+		refresh = c.refreshElements
+	}
+
+	for {
+		phrase := c.getRefreshedInput("SEARCH FOR:", refresh)
+		if phrase == nil {
+			continue
+		}
+
+		c.resetFlags()
+
+		if !c.runCommands(phrase) {
+			return
+		}
+
+		if len(phrase.Object) > 0 {
+			c.phrase = phrase
+			c.fetchData(c.phrase.Object)
+		}
+
+		if c.matches != nil {
+			if c.page == OBJECT_PAGE && len(phrase.Object) > 0 {
+				c.previousPage()
+			} else {
+				c.nextPage()
+			}
+			return
+		}
+	}
+}
+
+// Prints object's orbital elements. If alt is true, the altitude ASL
+// is displayed. If acc is true, the values are not shortened
+// and are displayed as exact numbers. If kozai is true, the values derived
+// from the Kozai mean motion are displayed alongside.
+func (c *Console) printElements(alt, acc, kozai bool) {
+	obj := c.currentElements()
+	elements := objectLines(obj, c.curProp, c.observer, alt, acc, kozai)
+
+	pterm.Println(GetTitle(obj))
+
+	time.Sleep(LONG_DELAY)
+
+	for i := range elements {
+		pterm.Println(" ", elements[i])
+		time.Sleep(SHORT_DELAY)
+	}
+
+	time.Sleep(MED_DELAY)
+}
+
+// Redraws the elements printed by printElements in place, without
+// the delays. The cursor position and the input typed so far are retained.
+func (c *Console) refreshElements() {
+	obj := c.currentElements()
+	elements := objectLines(obj, c.curProp, c.observer, !c.radius, c.precise, c.kozai)
+
+	// Save cursor position and move to the top of the page
+	pterm.Print("\0337\033[H")
+
+	for _, line := range strings.Split(GetTitle(obj), "\n") {
+		pterm.Println("\033[2K" + line)
+	}
+
+	for i := range elements {
+		pterm.Println("\033[2K ", elements[i])
+	}
+
+	// Restore cursor position
+	pterm.Print("\0338")
+}
+
+// Displays the ground track of the current object, starting at the time
+// of the displayed elements. Accepts the number of orbits and the step
+// between the points as arguments, 1 orbit and 1 minute by default.
+func (c *Console) showGroundTrack(args []string) {
+	var (
+		orbits float64       = 1
+		step   time.Duration = time.Minute
+		err    error
+	)
+
+	if !c.requirePropagator() {
+		return
+	}
+
+	if len(args) > 0 {
+		orbits, err = strconv.ParseFloat(args[0], 64)
+	}
+	if len(args) > 1 && err == nil {
+		step, err = ParseDuration(args[1])
+	}
+
+	var track []*orbit.GroundPoint
+
+	if err == nil {
+		start := time.Unix(c.currentElements().Time, 0)
+		track, err = orbit.GroundTrack(c.curProp, start, orbits, step)
+	}
+
+	c.clear()
+
+	pterm.Printfln("GROUND TRACK OF %s (%g orbits, step %s):\n", c.curObj.Name, orbits, step)
+
+	for _, gp := range track {
+		pterm.Printfln(
+			"  %s    %s    %s    %s",
+			gp.Time.UTC().Format("2006-01-02T15:04:05"),
+			ParamToString("Lat", gp.Lat, c.precise),
+			ParamToString("Lon", gp.Lon, c.precise),
+			ParamToString("Hgt", gp.Hgt, c.precise),
+		)
+	}
+
+	if err != nil {
+		pterm.Println(err)
+	}
+
+	c.offSetBy(1)
+	c.getInput("Press Enter to continue...")
+}
+
+// Displays the passes of the current object above the observer's horizon.
+// The search starts at the simulation time, or at the current time if
+// the simulation clock is not set. Accepts the length of the search
+// window as an argument, 1 day by default.
+func (c *Console) showPasses(args []string) {
+	var (
+		window time.Duration = 24 * time.Hour
+		err    error
+	)
+
+	if !c.requirePropagator() || !c.requireObserver() {
+		return
+	}
+
+	if len(args) > 0 {
+		window, err = ParseDuration(args[0])
+	}
+
+	start := c.searchStart()
+
+	var passes []*orbit.Pass
+
+	if err == nil {
+		passes, err = orbit.FindPasses(c.curProp, c.observer, start, start.Add(window))
+	}
+
+	c.clear()
+
+	pterm.Printfln(
+		"PASSES OF %s OVER %.4f %.4f (%d):\n",
+		c.curObj.Name, c.observer.Lat, c.observer.Lon, len(passes),
+	)
+	for _, line := range passLines(passes) {
+		pterm.Println(" ", line)
+	}
+
+	if err != nil {
+		pterm.Println(err)
+	}
+
+	c.offSetBy(1)
+	c.getInput("Press Enter to continue...")
+}
+
+// Displays the Doppler-corrected frequency over the current or the next
+// pass of the object, computed at one second intervals. If the pass is too
+// long to fit the screen, the samples at even intervals are listed, while
+// the CSV file contains all of them. Accepts the nominal frequency,
+// the direction ('up' or 'down', the default) and the name of the CSV file
+// the table is saved to as arguments.
+func (c *Console) showDoppler(args []string) {
+	var (
+		f      float64
+		uplink bool
+		file   string
+		table  []*orbit.DopplerSample
+		err    error
+	)
+
+	if !c.requirePropagator() || !c.requireObserver() {
+		return
+	}
+
+	if len(args) == 0 {
+		err = errors.New("expected arguments: frequency [up|down] [file.csv]")
+	} else {
+		f, err = ParseFrequency(args[0])
+	}
+
+	for _, arg := range args[min(len(args), 1):] {
+		switch arg {
+		case "up":
+			uplink = true
+		case "down":
+			uplink = false
+		default:
+			file = arg
+		}
+	}
+
+	var passes []*orbit.Pass
+
+	if err == nil {
+		start := c.searchStart()
+
+		passes, err = orbit.FindPasses(c.curProp, c.observer, start, start.Add(24*time.Hour))
+		if err == nil && len(passes) == 0 {
+			err = errors.New("no pass within 1 day")
+		}
+	}
+
+	if err == nil {
+		table, err = orbit.DopplerTable(c.curProp, c.observer, passes[0].AOS, passes[0].LOS, time.Second, f, uplink)
+	}
+
+	if err == nil && len(file) > 0 {
+		err = writeDopplerFile(file, table)
+	}
+
+	c.clear()
+
+	direction := "DOWNLINK"
+	if uplink {
+		direction = "UPLINK"
+	}
+
+	pterm.Printfln("%s DOPPLER FOR %s AT %.6f MHz:\n", direction, c.curObj.Name, f/1e6)
+
+	// The long passes are summarised to fit the screen, the file holds every sample
+	for _, line := range dopplerLines(table, RES_PER_PAGE) {
+		pterm.Println(" ", line)
+	}
+
+	if err != nil {
+		pterm.Println(err)
+	} else if len(file) > 0 {
+		pterm.Printfln("\nSaved to %s", file)
+	}
+
+	c.offSetBy(1)
+	c.getInput("Press Enter to continue...")
+}
+
+// Saves the Doppler table to the CSV file.
+func writeDopplerFile(name string, table []*orbit.DopplerSample) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return orbit.WriteDopplerCSV(f, table)
+}
+
+// Sets the value of the displayed element. Accepts the symbol of the element
+// and the value in SI units or degrees, optionally with a k, M or G prefix.
+// The epoch of the edited set is moved to the time of the displayed
+// elements, and the set is encoded again, so that the displayed values
+// are exactly those written by /tle command.
+func (c *Console) setElement(args []string) {
+	var (
+		value float64
+		err   error
+	)
+
+	if len(args) != 2 {
+		err = errors.New("expected arguments: symbol value, e.g. Ecc 0.001")
+	} else {
+		value, err = ParseNumber(args[1])
+	}
+
+	obj := c.currentElements()
+
+	if err == nil {
+		err = obj.Set(args[0], value)
+	}
+
+	var m *tle.Match
+
+	if err == nil {
+		set := obj.ToTLE(c.curTLE)
+		set.Match = &tle.Match{Title: c.curObj.Name}
+
+		m, err = tle.EncodeTLE(set)
+	}
+
+	if err != nil {
+		pterm.Println(err)
+		c.getInput("Press Enter to continue...")
+		return
+	}
+
+	set, err := tle.ParseMatch(m)
+	if err != nil {
+		Log(err)
+		return
+	}
+
+	c.setCurrent(set)
+}
+
+// Creates the object from the state vector given as arguments: the position
+// [m] and velocity [m/s] in the inertial frame of the dominant body,
+// at the simulation time or at the current time if the clock is not set.
+// The object page of the new object is displayed. If the arguments are
+// invalid, a message is displayed until the user presses Enter.
+func (c *Console) setState(args []string) {
+	t := time.Now()
+	if c.clock != nil {
+		t = c.clock.Now()
+	}
+
+	obj, err := ParseState(args, t, withConstants(c.body, c.consts))
+
+	var set *tle.TLE
+
+	if err == nil {
+		set, err = StateToTLE(obj)
+	}
+
+	if err != nil {
+		pterm.Println(err)
+		c.getInput("Press Enter to continue...")
+		return
+	}
+
+	// The set is encoded with limited precision, so it only drives
+	// the propagator, while the exact elements are displayed
+	c.setCurrent(set)
+	c.curObj = obj
+	c.page = OBJECT_PAGE
+}
+
+// Displays the current object as a three-line set. If the file name
+// is given as an argument, the set is saved to that file.
+func (c *Console) showTLE(args []string) {
+	m, err := tle.EncodeTLE(c.curTLE)
+
+	if err == nil && len(args) > 0 {
+		err = os.WriteFile(args[0], []byte(m.String()), 0644)
+	}
+
+	c.clear()
+
+	if m != nil {
+		pterm.Println(strings.ReplaceAll(m.String(), "\r", ""))
+	}
+
+	if err != nil {
+		pterm.Println(err)
+	} else if len(args) > 0 {
+		pterm.Printfln("Saved to %s", args[0])
+	}
+
+	c.offSetBy(1)
+	c.getInput("Press Enter to continue...")
+}
+
+// Displays the state vectors of the current object at the time
+// of the displayed elements, in the perifocal and inertial frames.
+func (c *Console) showStateVectors() {
+	obj := c.currentElements()
+
+	c.clear()
+
+	pterm.Printfln("STATE VECTORS OF %s (%s):\n", obj.Name, time.Unix(obj.Time, 0).UTC().Format("2006-01-02T15:04:05 UTC"))
+
+	for _, line := range stateLines(obj, c.precise) {
+		pterm.Println(" ", line)
+	}
+
+	c.offSetBy(1)
+	c.getInput("Press Enter to continue...")
+}
+
+// Writes the displayed elements to the file given as an argument.
+// The format is chosen by the extension of the file: .json or .csv.
+func (c *Console) exportElements(args []string) {
+	var err error
+
+	if len(args) != 1 {
+		err = errors.New("expected argument: file name ending with .json or .csv")
+	} else {
+		err = writeElementsFile(args[0], c.currentElements())
+	}
+
+	if err != nil {
+		pterm.Println(err)
+	} else {
+		pterm.Printfln("Saved to %s", args[0])
+	}
+
+	c.getInput("Press Enter to continue...")
+}
+
+// Writes the elements to the file in the format given by its extension.
+func writeElementsFile(name string, obj *orbit.Elements) error {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("unknown export format: %s", name)
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return writeElements(f, obj, format)
+}
+
+// Reads the sets from the file given as an argument and displays them
+// on the results page. If the file cannot be read, a message is displayed
+// until the user presses Enter.
+func (c *Console) openFile(args []string) {
+	var (
+		src *source.FileSource
+		err error
+	)
+
+	if len(args) != 1 {
+		err = errors.New("expected argument: file name")
+	} else {
+		src, err = source.NewFileSource(args[0])
+	}
+
+	if err == nil {
+		err = c.showSets(filepath.Base(args[0]), src)
+	}
+
+	if err != nil {
+		pterm.Println(err)
+		c.getInput("Press Enter to continue...")
+	}
+}
+
+// Displays all the sets of the file source on the results page, under
+// the specified label.
+func (c *Console) showSets(label string, src *source.FileSource) error {
+	matches, modified := src.Matches()
+	if len(matches) == 0 {
+		return fmt.Errorf("no sets found in %s", label)
+	}
+
+	c.phrase = &Phrase{Object: label}
+	c.matches = matches
+	c.fetched = modified
+	c.resPage = 0
+	c.local = true
+	c.page = RESULTS_PAGE
+
+	return nil
+}
+
+// Returns true if the observer location is set. Otherwise, displays
+// a message until the user presses Enter.
+func (c *Console) requireObserver() bool {
+	if c.observer != nil {
+		return true
+	}
+
+	pterm.Println("Observer location is not set, use /obs command.")
+	c.getInput("Press Enter to continue...")
+
+	return false
+}
+
+// Returns true if the propagator of the current object is available.
+// Otherwise, displays the reason until the user presses Enter.
+func (c *Console) requirePropagator() bool {
+	if c.curProp != nil {
+		return true
+	}
+
+	pterm.Println(c.propErr)
+	c.getInput("Press Enter to continue...")
+
+	return false
+}
+
+// Returns the time the predictions start at: the simulation time,
+// or the current time if the simulation clock is not set.
+func (c *Console) searchStart() time.Time {
+	if c.clock != nil {
+		return c.clock.Now()
+	}
+	return time.Now()
+}
+
+// Sets the observer location from the arguments: latitude, longitude
+// and optional height above the ellipsoid. If no arguments are given
+// or they are invalid, a message is displayed until the user presses Enter.
+func (c *Console) setObserver(args []string) {
+	switch {
+	case len(args) == 0 && c.observer == nil:
+		pterm.Println("Observer location is not set.")
+	case len(args) == 0:
+		pterm.Printfln("Observer location: %.4f %.4f %.0f", c.observer.Lat, c.observer.Lon, c.observer.Hgt)
+	default:
+		obs, err := ParseObserver(args)
+		if err == nil {
+			c.observer = obs
+			return
+		}
+
+		pterm.Println(err)
+	}
+
+	c.getInput("Press Enter to continue...")
+}
+
+// Sets the dominant body from the argument and recalculates the elements
+// of the current object. If no argument is given or it is invalid,
+// a message is displayed until the user presses Enter.
+func (c *Console) setBody(args []string) {
+	names := make([]string, len(orbit.Bodies))
+	for i, b := range orbit.Bodies {
+		names[i] = strings.ToLower(b.Name)
+	}
+
+	switch len(args) {
+	case 0:
+		pterm.Printfln("Dominant body: %s", c.body.Name)
+	case 1:
+		b, err := orbit.FindBody(args[0])
+		if err == nil {
+			c.body = b
+			if c.curTLE != nil {
+				c.setCurrent(c.curTLE)
+			}
+			return
+		}
+		pterm.Println(err)
+	default:
+		pterm.Println("Expected argument: body name")
+	}
+
+	pterm.Printfln("Available bodies: %s", strings.Join(names, ", "))
+	c.getInput("Press Enter to continue...")
+}
+
+// Sets the constants of Earth from the argument and recalculates
+// the elements of the current object. If no argument is given or it is
+// invalid, a message is displayed until the user presses Enter.
+func (c *Console) setConstants(args []string) {
+	names := make([]string, len(orbit.ConstantSets))
+	for i, b := range orbit.ConstantSets {
+		names[i] = b.Constants
+	}
+
+	switch len(args) {
+	case 0:
+		pterm.Printfln("Constants of Earth: %s", c.consts.Constants)
+	case 1:
+		b, err := orbit.FindConstants(args[0])
+		if err == nil {
+			c.consts = b
+			if c.curTLE != nil {
+				c.setCurrent(c.curTLE)
+			}
+			return
+		}
+		pterm.Println(err)
+	default:
+		pterm.Println("Expected argument: name of the set of constants")
+	}
+
+	pterm.Printfln("Available sets: %s", strings.Join(names, ", "))
+	c.getInput("Press Enter to continue...")
+}
+
+// Returns the elements of the current object at the simulation time.
+func (c *Console) currentElements() *orbit.Elements {
+	if c.clock == nil {
+		return c.curObj
+	}
+	return c.curObj.AdvanceTo(c.clock.Now().Unix())
+}
+
+// Applies the arguments of the time command to the simulation clock.
+// The 'epoch' argument removes the clock, so that the elements are displayed
+// at the epoch. If no arguments are given or they are invalid, a message
+// is displayed until the user presses Enter.
+func (c *Console) setClock(args []string) {
+	var clock Clock
+
+	if c.clock != nil {
+		clock = *c.clock
+	} else if c.curObj != nil {
+		clock = *NewClock(time.Unix(c.curObj.Epoch, 0), 1)
+	} else {
+		clock = *NewClock(time.Now(), 1)
+	}
+
+	switch {
+	case len(args) == 0 && c.clock == nil:
+		pterm.Println("Simulation time: epoch")
+	case len(args) == 0:
+		pterm.Printfln("Simulation time: %s  x%g", c.clock.Now().UTC().Format(time.RFC3339), c.clock.Rate())
+	case Contains(args, "epoch"):
+		c.clock = nil
+		return
+	default:
+		err := clock.Parse(args)
+		if err == nil {
+			c.clock = &clock
+			return
+		}
+		pterm.Println(err)
+	}
+
+	c.getInput("Press Enter to continue...")
+}
+
+// Displays the current page of found results.
+func (c *Console) printMatches() {
+	pterm.Printf("RESULTS FOR %s (%d)%s:\n\n", c.phrase.Object, len(c.matches), c.dataAge())
+
+	time.Sleep(LONG_DELAY)
+
+	var lastI int
+
+	if len(c.matches) < c.resPage*RES_PER_PAGE+RES_PER_PAGE {
+		lastI = len(c.matches)
+	} else {
+		lastI = c.resPage*RES_PER_PAGE + RES_PER_PAGE
+	}
+
+	for i := c.resPage * RES_PER_PAGE; i < lastI; i++ {
+		// Titles longer than the column are shortened
+		title := []rune(strings.TrimSpace(c.matches[i].Title))
+		if len(title) > 25 {
+			title = append(title[:24], '~')
+		}
+
+		pterm.Printf(
+			"%9d |  NAME:%25s     NORAD SIG:%8s  | %2d\n",
+			i+1, string(title), c.matches[i].GetCatNum(), i+1,
+		)
+		time.Sleep(SHORT_DELAY)
+	}
+
+	time.Sleep(MED_DELAY)
+	pterm.Printf("%66s     %d / %d", "PAGE:", c.resPage+1, c.pageCount())
+	time.Sleep(MED_DELAY)
+}
+
+// Returns the label describing the age of the current matches. The label
+// is empty if the data has just been downloaded or read from stdin. For local
+// files, the age of the most recently modified file is given.
+func (c *Console) dataAge() string {
+	age := time.Since(c.fetched)
+
+	if c.local {
+		if c.fetched.IsZero() {
+			return ""
+		}
+		return ", MODIFIED " + FormatDuration(int64(age.Seconds()))[1:] + " AGO"
+	}
+
+	if age < time.Minute && !c.offline {
+		return ""
+	}
+
+	label := ", CACHED " + FormatDuration(int64(age.Seconds()))[1:] + " AGO"
+	if c.offline {
+		label = ", OFFLINE" + label
+	}
+
+	return label
+}
+
+// Returns true if the source reads the sets from local files.
+func isLocal(src source.Source) bool {
+	switch src.(type) {
+	case *source.DirSource, *source.FileSource:
+		return true
+	default:
+		return false
+	}
+}
+
+// Skips to the next page.
+func (c *Console) nextPage() {
+	if c.page < OBJECT_PAGE {
+		c.page++
+	}
+}
+
+// Skips to the previous page.
+func (c *Console) previousPage() {
+	if c.page > EXIT {
+		c.page--
+	}
+}
+
+// Clear console window.
+func (c *Console) clear() {
+	pterm.Print("\033[H\033[2J")
+}
+
+// Offsets the current cursor position by n lines.
+func (c *Console) offSetBy(n int) {
+	for i := 0; i < n; i++ {
+		pterm.Println()
+	}
+}
+
+// Restores the remembered terminal window size.
+func (c *Console) restoreTerminalSize() {
+	c.setWorkingHeight(c.oldH, c.oldW)
+}
+
+// Sets the terminal height and width according to the values passed.
+func (c *Console) setWorkingHeight(h, w int) {
+	pterm.Printfln("\x1b[8;%d;%dt", h, w)
+}
+
+// Parses the command that changes the matches currently displayed
+// on the result page.
+func (c *Console) parseSwitchResPageCommand(cmd string, direction int) {
+	if len(cmd) == 1 {
+		c.switchResPage(direction)
+		return
+	}
+
+	n, err := strconv.Atoi(cmd[1:])
+	if err != nil {
+		return
+	}
+
+	c.switchResPage(n * direction)
+}
+
+// Sets new results page number.
+func (c *Console) switchResPage(by int) {
+	newN := c.resPage + by
+	if newN >= 0 && newN < c.pageCount() {
+		c.resPage = newN
+	}
+}
+
+// Returns the number of results pages.
+func (c *Console) pageCount() int {
+	return int(math.Ceil(float64(len(c.matches)) / float64(RES_PER_PAGE)))
+}
+
+// Runs commands contained within the Phrase. Returns true if any further
+// action should be taken by the calling function (e.g. proceed with query).
+func (c *Console) runCommands(phrase *Phrase) bool {
+	if Contains(phrase.Commands, "b") {
+		c.previousPage()
+		return false
+	} else if Contains(phrase.Commands, "e") {
+		c.page = EXIT
+		return false
+	} else if Contains(phrase.Commands, "h") {
+		c.showHelpPage()
+		return false
+	}
+
+	// Time commands are valid on every page. The arguments of '/t' are
+	// consumed, so that they are not mistaken for a query.
+	if Contains(phrase.Commands, "t") {
+		c.setClock(phrase.Args)
+		return false
+	} else if Contains(phrase.Commands, "obs") {
+		c.setObserver(phrase.Args)
+		return false
+	} else if Contains(phrase.Commands, "open") {
+		c.openFile(phrase.Args)
+		return false
+	} else if Contains(phrase.Commands, "body") {
+		c.setBody(phrase.Args)
+		return false
+	} else if Contains(phrase.Commands, "const") {
+		c.setConstants(phrase.Args)
+		return false
+	} else if Contains(phrase.Commands, "rv") {
+		c.setState(phrase.Args)
+		return false
+	} else if Contains(phrase.Commands, "l") {
+		c.clock = NewClock(time.Now(), 1)
+	} else if Contains(phrase.Commands, "ep") {
+		c.clock = nil
+	}
+
+	if Contains(phrase.Commands, "c") {
+		c.queryKind = source.QUERY_CATNR
+	} else if Contains(phrase.Commands, "i") {
+		c.queryKind = source.QUERY_INTDES
+	} else if Contains(phrase.Commands, "g") {
+		c.queryKind = source.QUERY_GROUP
+	} else if Contains(phrase.Commands, "sp") {
+		c.queryKind = source.QUERY_SPECIAL
+	} else { // if does or does not contain '/n' - the default search mode
+		c.queryKind = source.QUERY_NAME
+	}
+
+	switch c.page {
+	case START_PAGE:
+		if Contains(phrase.Commands, "f") && len(c.matches) > 0 {
+			c.page = RESULTS_PAGE
+		} else if len(phrase.Object) >= source.MIN_QLEN {
+			c.setFlags(phrase)
+			return true
+		} else if len(phrase.Object) < source.MIN_QLEN {
+			return false
+		}
+	case RESULTS_PAGE:
+		rightIdx := ContainsPart(phrase.Commands, ">")
+		leftIdx := ContainsPart(phrase.Commands, "<")
+
+		if Contains(phrase.Commands, "f") && c.curObj != nil {
+			c.page = OBJECT_PAGE
+		} else if rightIdx > -1 {
+			c.parseSwitchResPageCommand(phrase.Commands[rightIdx], 1)
+		} else if leftIdx > -1 {
+			c.parseSwitchResPageCommand(phrase.Commands[leftIdx], -1)
+		} else {
+			return true
+		}
+	case OBJECT_PAGE:
+		if Contains(phrase.Commands, "gt") {
+			c.setFlags(phrase)
+			c.showGroundTrack(phrase.Args)
+			return false
+		} else if Contains(phrase.Commands, "passes") {
+			c.showPasses(phrase.Args)
+			return false
+		} else if Contains(phrase.Commands, "dop") {
+			c.showDoppler(phrase.Args)
+			return false
+		} else if Contains(phrase.Commands, "set") {
+			c.setElement(phrase.Args)
+			return false
+		} else if Contains(phrase.Commands, "tle") {
+			c.showTLE(phrase.Args)
+			return false
+		} else if Contains(phrase.Commands, "exp") {
+			c.exportElements(phrase.Args)
+			return false
+		} else if Contains(phrase.Commands, "sv") {
+			c.setFlags(phrase)
+			c.showStateVectors()
+			return false
+		}
+
+		if len(phrase.Object) >= source.MIN_QLEN || len(phrase.Commands) > 0 {
+			c.setFlags(phrase)
+			return true
+		}
+	}
+	return false
+}
+
+// Sets display flags according to commands contained within the Phrase.
+func (c *Console) setFlags(phrase *Phrase) {
+	if Contains(phrase.Commands, "a") {
+		c.radius = false
+	} else if Contains(phrase.Commands, "r") {
+		c.radius = true
+	}
+
+	if Contains(phrase.Commands, "p") {
+		c.precise = true
+	} else if Contains(phrase.Commands, "s") {
+		c.precise = false
+	}
+
+	if Contains(phrase.Commands, "k") {
+		c.kozai = true
+	} else if Contains(phrase.Commands, "nk") {
+		c.kozai = false
+	}
+}
+
+// Resets display flags.
+func (c *Console) resetFlags() {
+	c.radius = true
+	c.precise = false
+	c.kozai = false
+	c.queryKind = source.QUERY_NAME
+}
+
+// Sets up the new console interface. The data is requested from src.
+// If offline is true, the data is served from cache only. The commands
+// are read from in.
+func NewConsole(src source.Source, offline bool, in io.Reader) *Console {
+	var c Console
+
+	c.oldH = pterm.GetTerminalHeight()
+	c.oldW = pterm.GetTerminalWidth()
+
+	c.page = START_PAGE
+
+	c.src = src
+	c.offline = offline
+	c.body = orbit.Earth
+	c.consts = orbit.Earth
+	c.scanner = bufio.NewScanner(in)
+	c.input = make(chan string)
+
+	go c.readInput()
+
+	c.resetFlags()
+
+	c.setWorkingHeight(TERM_HEIGHT, TERM_WIDTH)
+
+	return &c
+}
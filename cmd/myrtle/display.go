@@ -0,0 +1,399 @@
+// Functions within this file format the orbital elements and predictions
+// for display in the console and in the output of the subcommands.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Zedran/myrtle/orbit"
+)
+
+// Creates a title string consisting of the object name, dominant body, dates
+// and original set lines.
+// If the elements were advanced from the epoch, the time elapsed since
+// the epoch is displayed below the set lines.
+func GetTitle(e *orbit.Elements) string {
+	mjd := orbit.JDNToMJD(orbit.UnixToJDN(e.Time))
+	date := time.Unix(e.Time, 0).UTC().Format("2006-01-02T15:04:05 UTC")
+
+	var elapsed string
+
+	if e.Time != e.Epoch {
+		elapsed = fmt.Sprintf("    %s since epoch", FormatDuration(e.Time-e.Epoch))
+	}
+
+	name := e.Name
+	if len(e.Body) > 0 {
+		name += "    " + strings.ToUpper(e.Body)
+	}
+	if len(e.Constants) > 0 {
+		name += " " + e.Constants
+	}
+
+	return fmt.Sprintf("%s    MJD %.5f    %4s\n    %4s\n    %4s\n%s\n", name, mjd, date, e.L1, e.L2, elapsed)
+}
+
+// Converts the Elements struct fields into a slice of strings. If alt is true,
+// the distance will be displayed in relation to the dominant body's reference
+// ellipsoid (ASL) instead of measuring it from the body's center. If acc is
+// true, the numbers are not crunched by FormatNumber function. This increases
+// their precision, but reduces readability.
+func ElementsToString(e *orbit.Elements, alt, acc bool) []string {
+	var (
+		// These variables are different depending on the reference point. If alt is true,
+		// then periapsis, apoapsis and radius are replaced with altitudes ASL.
+		// The aforementioned three variables are also named differently.
+		peV, apV, rV float64
+		pe, ap, r    string
+
+		// Eccentric anomaly has '!' appended to its symbol if solution for it does not converge.
+		eca string = "EcA"
+	)
+
+	if e.EcAConvErr {
+		eca += "!"
+	}
+
+	if alt {
+		// Altitudes
+		pe, peV = "PeA", e.PeA
+		ap, apV = "ApA", e.ApA
+		r, rV = "Alt", e.Alt
+	} else {
+		// Radii
+		pe, peV = "PeR", e.PeR
+		ap, apV = "ApR", e.ApR
+		r, rV = "R", e.R
+	}
+
+	return []string{
+		ParamToString("SMa", e.SMa, acc),
+		ParamToString("SMi", e.SMi, acc),
+		ParamToString(pe, peV, acc),
+		ParamToString(ap, apV, acc),
+		ParamToString(r, rV, acc),
+		ParamToString("Ecc", e.Ecc, acc),
+		ParamToString("T", e.T, acc),
+		ParamToString("PeT", e.PeT, acc),
+		ParamToString("ApT", e.ApT, acc),
+		ParamToString("Vel", e.Vel, acc),
+		ParamToString("Inc", e.Inc, acc),
+		ParamToString("LAN", e.LAN, acc),
+		ParamToString("LPe", e.LPe, acc),
+		ParamToString("AgP", e.AgP, acc),
+		ParamToString("TrA", e.TrA, acc),
+		ParamToString("TrL", e.TrL, acc),
+		ParamToString("MnA", e.MnA, acc),
+		ParamToString("MnL", e.MnL, acc),
+		ParamToString(eca, e.EcA, acc),
+	}
+}
+
+// Ensures the proper display format of the orbital element depending
+// on its type. If accurate is true, the value is not submitted
+// to FormatNumber function. This means it will be represented with maximum
+// precision and reduced readability.
+func ParamToString(symbol string, value float64, accurate bool) string {
+	if accurate {
+		return fmt.Sprintf("%-5s%f", symbol, value)
+	}
+
+	var n string
+
+	switch symbol {
+	case "SMa", "SMi", "PeR", "ApR", "R", "Rng":
+		n = FormatNumber(value, 5, 3, false, true)
+	case "PeA", "ApA", "Alt", "Hgt":
+		n = FormatNumber(value, 5, 1, false, true)
+	case "Ecc":
+		n = FormatNumber(value, 6, 4, false, false)
+	case "T", "PeT", "ApT", "Vel", "RRt":
+		n = FormatNumber(value, 5, 3, false, true)
+	default: // Angles
+		n = FormatNumber(value, 6, 2, true, false)
+	}
+
+	return fmt.Sprintf("%-5s%s", symbol, n)
+}
+
+// Formats the number n. leftPadding and precision are parameters for sprintf
+// ensuring a proper placement of the number. isAngle causes the function
+// to treat the number as an angle - a degree sign is appended and the
+// reduction is omitted, since the angles never reach 1e3.
+// adjustPrecision is a parameter that corrects the length of the fractional
+// part to ensure the proper alignment of all values. It is needed to trim
+// the number to a specific width, regardless of whether the negation sign
+// is present or the integral part's digit count. It should be set to false
+// for eccentricity and angular values, since they have a set precision
+// and are never negative.
+func FormatNumber(n float64, leftPadding, precision int, isAngle, adjustPrecision bool) string {
+	const (
+		// A degree symbol in unicode
+		deg string = "\u00b0"
+
+		div float64 = 1e3
+
+		templ string = "%%%d.%df%%s"
+	)
+
+	// Prefixes indicating the order of magnitude
+	var pfx [9]string = [9]string{"", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+	if isAngle {
+		format := fmt.Sprintf(templ, leftPadding, precision)
+		return fmt.Sprintf(format, n, deg)
+	}
+
+	var sign float64
+	if n < 0 {
+		n = math.Abs(n)
+		sign = -1
+	} else {
+		sign = 1
+	}
+
+	var i int
+	for i = 0; i < len(pfx) && n > div; i++ {
+		n /= div
+	}
+
+	// Adjusts precision according to the number of digits in a number
+	if adjustPrecision {
+		switch {
+		case n < 10:
+			precision = 3
+		case n < 100:
+			precision = 2
+		default:
+			precision = 1
+		}
+
+		// The precision is reduced if the minus symbol occupies one place
+		if sign < 0 {
+			precision--
+		}
+	}
+
+	format := fmt.Sprintf(templ, leftPadding, precision)
+
+	return fmt.Sprintf(format, n*sign, pfx[i])
+}
+
+// Formats the time span given in seconds as a signed number of days,
+// followed by hours, minutes and seconds, e.g. "+3d 04:12:55".
+func FormatDuration(seconds int64) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	return fmt.Sprintf(
+		"%s%dd %02d:%02d:%02d",
+		sign, seconds/86400, seconds%86400/3600, seconds%3600/60, seconds%60,
+	)
+}
+
+// Returns the lines of the object page below the title. The orbital
+// elements are displayed in the left column, the values describing
+// the object's position relative to Earth in the right one. If kozai
+// is true, the values derived from the Kozai mean motion are listed
+// in the right column, below the position. The warnings are listed
+// below both columns.
+func objectLines(obj *orbit.Elements, prop *orbit.SGP4, obs *orbit.Observer, alt, acc, kozai bool) []string {
+	const colWidth int = 30
+
+	lines := ElementsToString(obj, alt, acc)
+
+	right := positionLines(obj, prop, obs, acc)
+	if kozai {
+		if len(right) > 0 {
+			right = append(right, "")
+		}
+		right = append(right, kozaiLines(obj, alt, acc)...)
+	}
+
+	for len(lines) < len(right) {
+		lines = append(lines, "")
+	}
+
+	for i, s := range right {
+		if len(s) > 0 {
+			lines[i] += strings.Repeat(" ", max(colWidth-utf8.RuneCountInString(lines[i]), 1)) + s
+		}
+	}
+
+	// Warnings are listed below the table, so that they never hide its values
+	if warnings := warningLines(obj); len(warnings) > 0 {
+		lines = append(append(lines, ""), warnings...)
+	}
+
+	return lines
+}
+
+// Returns the semi-major axis, periapsis, apoapsis and period derived
+// from the Kozai mean motion of TLE, as if it were Keplerian, preceded
+// by the header. If alt is true, altitudes are returned instead of radii.
+func kozaiLines(obj *orbit.Elements, alt, acc bool) []string {
+	pe, ap := "PeR", "ApR"
+	peV := orbit.PeriapsisRadius(obj.KSMa, obj.Ecc)
+	apV := orbit.ApoapsisRadius(obj.KSMa, obj.Ecc)
+
+	if alt {
+		pe, ap = "PeA", "ApA"
+		peV = orbit.EllipsoidAltitude(peV, obj.Inc, obj.AgP, obj.DA, obj.DF)
+		apV = orbit.EllipsoidAltitude(apV, obj.Inc, obj.AgP+180, obj.DA, obj.DF)
+	}
+
+	return []string{
+		"KOZAI:",
+		ParamToString("SMa", obj.KSMa, acc),
+		ParamToString(pe, peV, acc),
+		ParamToString(ap, apV, acc),
+		ParamToString("T", obj.KT, acc),
+	}
+}
+
+// Returns the table of the state vectors of the object: the position [m]
+// and velocity [m/s] in the perifocal (PF) and inertial (ECI) frames,
+// preceded by the header. The inertial frame is the one the elements
+// refer to, i.e. TEME for TLE sets.
+func stateLines(obj *orbit.Elements, acc bool) []string {
+	pr, pv := obj.PerifocalState()
+	r, v := obj.InertialState()
+
+	rows := []struct {
+		label string
+		vec   orbit.Vector
+	}{
+		{"PF  r", pr},
+		{"PF  v", pv},
+		{"ECI r", r},
+		{"ECI v", v},
+	}
+
+	lines := []string{fmt.Sprintf("%-6s%16s%16s%16s%16s", "", "X", "Y", "Z", "|X|")}
+
+	for _, row := range rows {
+		line := fmt.Sprintf("%-6s", row.label)
+		for _, n := range append(row.vec[:], row.vec.Norm()) {
+			if acc {
+				line += fmt.Sprintf("%16.3f", n)
+			} else {
+				line += fmt.Sprintf("%16s", FormatNumber(n, 5, 3, false, true))
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// Returns the warnings about the TLE fields that could not be parsed,
+// along with their content. The values of these fields are zero.
+func warningLines(obj *orbit.Elements) []string {
+	if len(obj.Warnings) == 0 {
+		return nil
+	}
+
+	lines := []string{"WARNINGS:"}
+
+	for _, w := range obj.Warnings {
+		lines = append(lines, fmt.Sprintf("! L%d %d-%d %s %q", w.Line, w.First, w.Last, w.Field, w.Value))
+	}
+
+	return lines
+}
+
+// Returns the values derived from the propagated state of the object
+// at the time of the elements. If the observer is not nil, the look angles
+// are included. If the propagation fails, the error is displayed instead.
+func positionLines(obj *orbit.Elements, prop *orbit.SGP4, obs *orbit.Observer, acc bool) []string {
+	if prop == nil {
+		return nil
+	}
+
+	t := time.Unix(obj.Time, 0)
+
+	gp, err := orbit.SubSatellitePoint(prop, t)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	lines := []string{
+		ParamToString("Lat", gp.Lat, acc),
+		ParamToString("Lon", gp.Lon, acc),
+		ParamToString("Hgt", gp.Hgt, acc),
+	}
+
+	if obs == nil {
+		return lines
+	}
+
+	look, err := obs.Look(prop, t)
+	if err != nil {
+		return append(lines, "", err.Error())
+	}
+
+	return append(lines,
+		"",
+		ParamToString("Az", look.Az, acc),
+		ParamToString("El", look.El, acc),
+		ParamToString("Rng", look.Rng, acc),
+		ParamToString("RRt", look.RRt, acc),
+	)
+}
+
+// Returns the table of passes: the times and azimuths of AOS and LOS,
+// and the time and elevation of the highest point, preceded by the header.
+func passLines(passes []*orbit.Pass) []string {
+	lines := []string{fmt.Sprintf("%-19s  %7s    %-8s  %7s    %-8s  %7s", "AOS", "AZ", "TCA", "MAX EL", "LOS", "AZ")}
+
+	for _, p := range passes {
+		lines = append(lines, fmt.Sprintf(
+			"%s  %s    %s  %s    %s  %s",
+			p.AOS.UTC().Format("2006-01-02 15:04:05"),
+			FormatNumber(p.AOSAz, 6, 1, true, false),
+			p.TCA.UTC().Format("15:04:05"),
+			FormatNumber(p.MaxEl, 6, 1, true, false),
+			p.LOS.UTC().Format("15:04:05"),
+			FormatNumber(p.LOSAz, 6, 1, true, false),
+		))
+	}
+
+	return lines
+}
+
+// Returns the Doppler table, preceded by the header. If the table is longer
+// than the number of rows, only the samples at even intervals are listed,
+// including the first and the last one, so that it fits the screen.
+func dopplerLines(table []*orbit.DopplerSample, rows int) []string {
+	lines := []string{fmt.Sprintf("%-8s  %7s  %9s  %13s  %9s  %9s", "TIME", "EL", "RRt", "FREQ [Hz]", "SHIFT", "RATE")}
+
+	// Intervals between the listed samples, rounded up
+	every := 1
+	if len(table) > rows && rows > 1 {
+		every = (len(table) - 1 + rows - 2) / (rows - 1)
+	}
+
+	for i, ds := range table {
+		if i%every != 0 && i != len(table)-1 {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"%s  %s  %s  %13.0f  %+9.0f  %+9.1f",
+			ds.Time.UTC().Format("15:04:05"),
+			FormatNumber(ds.El, 6, 1, true, false),
+			FormatNumber(ds.RRt, 9, 3, false, true),
+			ds.Freq, ds.Shift, ds.Rate,
+		))
+	}
+
+	return lines
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zedran/myrtle/orbit"
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Test case data structure for TestFormatNumber.
+type formatNumberCase struct {
+	// Tested number
+	Number float64
+
+	// Correct output
+	CorString string
+
+	// Actual output
+	Output string
+
+	// FormatNumber function parameters
+	leftPadding     int
+	precision       int
+	isAngle         bool
+	adjustPrecision bool
+
+	// Positive test completion indicator
+	Passed bool
+}
+
+// Runs the test and compares its output with the expected string.
+func (tc *formatNumberCase) run() {
+	tc.Output = FormatNumber(tc.Number, tc.leftPadding, tc.precision, tc.isAngle, tc.adjustPrecision)
+	tc.Passed = (tc.Output == tc.CorString)
+}
+
+// A constructor for formatNumberCase struct.
+func createCase(n float64, corOut string, pad, prec int, isAngle, adjustPrec bool) *formatNumberCase {
+	return &formatNumberCase{n, corOut, "", pad, prec, isAngle, adjustPrec, false}
+}
+
+// Test for FormatNumber function. Every output must be exactly equal
+// to the expected string.
+func TestFormatNumber(t *testing.T) {
+	// A degree symbol in unicode
+	const deg = "\u00b0"
+
+	cases := []*formatNumberCase{
+		// Elements related to radius, time and velocity
+		createCase(-4.125e6, "-4.12M", 5, 3, false, true),
+		createCase(6.717e6, "6.717M", 5, 3, false, true),
+
+		// Elements related to altitude ASL
+		createCase(45.90e3, "45.90k", 5, 1, false, true),
+		createCase(646.5e3, "646.5k", 5, 1, false, true),
+		createCase(-45.90e3, "-45.9k", 5, 1, false, true),
+		createCase(-646.5e3, " -646k", 5, 1, false, true),
+
+		// Orbital eccentricity
+		createCase(0.0000, "0.0000", 6, 4, false, false),
+		createCase(0.0447, "0.0447", 6, 4, false, false),
+
+		// Angles
+		createCase(48.78, " 48.78"+deg, 6, 2, true, false),
+		createCase(210.30, "210.30"+deg, 6, 2, true, false),
+		createCase(2.77, "  2.77"+deg, 6, 2, true, false),
+	}
+
+	passed := true
+	for i := range cases {
+		cases[i].run()
+		passed = passed && cases[i].Passed
+	}
+
+	if !passed {
+		for i := range cases {
+			if !cases[i].Passed {
+				t.Logf("'%f' : '%s'\n", cases[i].Number, cases[i].Output)
+			}
+		}
+		t.Fatal("Test failed for cases listed above.")
+	}
+}
+
+// Tests objectLines function with a set carrying many warnings. The warnings
+// must be listed below the table and must not hide any of its lines.
+func TestObjectLinesWarnings(t *testing.T) {
+	set, err := tle.ParseMatch(tle.Parse([]byte(sample))[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop, err := orbit.NewSGP4(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obs, err := orbit.NewObserver(52.23, 21.01, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := orbit.CalculateElementsAround(set, orbit.Earth)
+	table := objectLines(obj, prop, obs, true, false, true)
+
+	for i := 0; i < 20; i++ {
+		obj.Warnings = append(obj.Warnings, &tle.TLEError{Line: 1, First: 54, Last: 61, Field: "BSTAR", Value: "-2OO61-4"})
+	}
+
+	lines := objectLines(obj, prop, obs, true, false, true)
+
+	if len(lines) != len(table)+len(obj.Warnings)+2 {
+		t.Fatalf("Expected %d lines, got %d", len(table)+len(obj.Warnings)+2, len(lines))
+	}
+
+	for i, line := range table {
+		if lines[i] != line {
+			t.Fatalf("Line %d hidden: %q, expected %q", i, lines[i], line)
+		}
+	}
+
+	if lines[len(table)+1] != "WARNINGS:" {
+		t.Fatalf("Warnings not below the table: %q", lines[len(table)+1])
+	}
+}
+
+// Tests dopplerLines function. The tables not longer than the number
+// of rows must be listed whole, the longer ones must fit in it and still
+// begin and end with the first and the last sample.
+func TestDopplerLines(t *testing.T) {
+	const rows = 20
+
+	start := time.Date(2022, 1, 14, 12, 0, 0, 0, time.UTC)
+
+	for _, n := range []int{1, 19, 20, 21, 39, 600, 601} {
+		table := make([]*orbit.DopplerSample, n)
+		for i := range table {
+			table[i] = &orbit.DopplerSample{Time: start.Add(time.Duration(i) * time.Second)}
+		}
+
+		lines := dopplerLines(table, rows)[1:]
+
+		if len(lines) > rows || (n <= rows && len(lines) != n) || (n > rows && len(lines) < rows/2) {
+			t.Fatalf("%d samples: %d rows listed", n, len(lines))
+		}
+
+		first, last := table[0].Time.Format("15:04:05"), table[n-1].Time.Format("15:04:05")
+		if !strings.HasPrefix(lines[0], first) || !strings.HasPrefix(lines[len(lines)-1], last) {
+			t.Fatalf("%d samples: listed from %q to %q", n, lines[0], lines[len(lines)-1])
+		}
+	}
+}
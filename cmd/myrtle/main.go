@@ -0,0 +1,145 @@
+// MyRTLE is a console application that displays orbital elements
+// and predictions for the objects described by TLE sets.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Zedran/myrtle/source"
+	"github.com/Zedran/myrtle/tle"
+)
+
+func main() {
+	offline := flag.Bool("offline", false, "serve the data from cache only")
+	expiry := flag.Duration("cache-expiry", source.DEFAULT_CACHE_EXPIRY, "age after which the cached data is downloaded again")
+	formatName := flag.String("format", string(tle.FORMAT_TLE), "format of the requested data: TLE, JSON, XML, CSV or KVN")
+	location := flag.String("source", source.CELESTRAK_URL, "base URL of the API compatible with CelesTrak, or a directory containing TLE files")
+	flag.Usage = usage
+	flag.Parse()
+
+	format, err := tle.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := openSource(*location, format, *expiry, *offline)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if flag.NArg() > 0 {
+		c := cli{src: src, out: os.Stdout}
+
+		if err := c.run(flag.Args()); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Piped data occupies stdin, the commands are read from the terminal
+	var in io.Reader = os.Stdin
+
+	if *location == "-" {
+		tty, err := openTerminal()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer tty.Close()
+		in = tty
+	}
+
+	console := NewConsole(src, *offline, in)
+
+	if fs, ok := src.(*source.FileSource); ok {
+		label := filepath.Base(*location)
+		if *location == "-" {
+			label = "STDIN"
+		}
+
+		if err := console.showSets(label, fs); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	console.Run()
+}
+
+// Creates the source of the element sets. Locations starting with http://
+// or https:// are queried as the API, with the responses cached on disk.
+// The "-" location is stdin, from which the sets are read in the specified
+// format. Any other location is a local file or directory.
+func openSource(location string, format tle.Format, expiry time.Duration, offline bool) (source.Source, error) {
+	if location == "-" {
+		return source.NewStreamSource(os.Stdin, format)
+	}
+
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		info, err := os.Stat(location)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.IsDir() {
+			return source.NewDirSource(location)
+		}
+		return source.NewFileSource(location)
+	}
+
+	client := http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+		},
+	}
+
+	cache, err := openCache(expiry, offline)
+	if err != nil {
+		if offline {
+			return nil, err
+		}
+		Log(err)
+	}
+
+	return source.NewHTTPSource(&client, location, cache, format), nil
+}
+
+// Prints the usage message, listing the subcommands and the options.
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: myrtle [options] [command [command options]]\n\n%s\nOptions:\n", COMMANDS_USAGE)
+	flag.PrintDefaults()
+}
+
+// Opens the cache in the default directory.
+func openCache(expiry time.Duration, offline bool) (*source.Cache, error) {
+	dir, err := source.DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return source.NewCache(dir, expiry, offline)
+}
+
+// Opens the terminal for reading.
+func openTerminal() (*os.File, error) {
+	if runtime.GOOS == "windows" {
+		return os.Open("CONIN$")
+	}
+	return os.Open("/dev/tty")
+}
@@ -9,6 +9,9 @@ type Phrase struct {
 	// Name or catalog number of an object
 	Object string
 
+	// All the words that are not commands, Object being the first of them
+	Args []string
+
 	// A slice of commands passed
 	Commands []string
 }
@@ -26,11 +29,15 @@ func NewPhrase(queryString string) *Phrase {
 	for i := range words {
 		if strings.HasPrefix(words[i], COMMAND_PREFIX) {
 			phrase.Commands = append(phrase.Commands, extractCommands(words[i])...)
-		} else if len(phrase.Object) == 0 {
-			phrase.Object = words[i]
+		} else if len(words[i]) > 0 {
+			phrase.Args = append(phrase.Args, words[i])
 		}
 	}
 
+	if len(phrase.Args) > 0 {
+		phrase.Object = phrase.Args[0]
+	}
+
 	phrase.Commands = RemoveDuplicates(phrase.Commands)
 
 	return &phrase
@@ -16,4 +16,10 @@ func TestNewPhrase(t *testing.T) {
 	if len(case2.Commands) != 0 || case2.Object != "iss" {
 		t.Fatalf("Case2: %s; %v", case2.Object, case2.Commands)
 	}
+
+	case3 := NewPhrase("/t 2026-10-20T12:00:00Z  x60")
+
+	if len(case3.Args) != 2 || case3.Object != case3.Args[0] || case3.Args[1] != "x60" {
+		t.Fatalf("Case3: %s; %v", case3.Object, case3.Args)
+	}
 }
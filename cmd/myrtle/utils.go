@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zedran/myrtle/orbit"
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Returns true if sequence is inside slice s.
+func Contains(s []string, seq string) bool {
+	for i := range s {
+		if s[i] == seq {
+			return true
+		}
+	}
+	return false
+}
+
+// Looks for part of a string inside a slice index. Returns index or -1 if nothing is found.
+func ContainsPart(s []string, seq string) int {
+	for i := range s {
+		if strings.Contains(s[i], seq) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Extends time.ParseDuration with the day unit, e.g. "3d", "-1d12h".
+// An optional sign applies to the whole duration.
+func ParseDuration(s string) (time.Duration, error) {
+	errInvalid := fmt.Errorf("invalid duration: %s", s)
+
+	body := strings.TrimPrefix(s, "+")
+
+	var sign time.Duration = 1
+	if strings.HasPrefix(body, "-") {
+		sign = -1
+		body = body[1:]
+	}
+
+	if len(body) == 0 {
+		return 0, errInvalid
+	}
+
+	var d time.Duration
+
+	if i := strings.Index(body, "d"); i > -1 {
+		days, err := strconv.ParseFloat(body[:i], 64)
+		if err != nil {
+			return 0, errInvalid
+		}
+		d = time.Duration(days * float64(24*time.Hour))
+		body = body[i+1:]
+	}
+
+	if len(body) > 0 {
+		rest, err := time.ParseDuration(body)
+		if err != nil {
+			return 0, errInvalid
+		}
+		d += rest
+	}
+
+	return sign * d, nil
+}
+
+// Parses the frequency in Hz, optionally followed by a k, M or G prefix
+// and the unit, e.g. "437.8M", "145.9MHz" or "2.2e9".
+func ParseFrequency(s string) (float64, error) {
+	f, err := ParseNumber(strings.TrimSuffix(s, "Hz"))
+	if err != nil || f <= 0 {
+		return 0, fmt.Errorf("invalid frequency: %s", s)
+	}
+
+	return f, nil
+}
+
+// Parses the number optionally followed by a k, M or G prefix,
+// the same as displayed by FormatNumber, e.g. "6.79M" or "-1.5k".
+func ParseNumber(s string) (float64, error) {
+	prefixes := map[string]float64{"k": 1e3, "M": 1e6, "G": 1e9}
+
+	num := s
+	mult := 1.0
+
+	if len(num) > 0 {
+		if m, ok := prefixes[num[len(num)-1:]]; ok {
+			num = num[:len(num)-1]
+			mult = m
+		}
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", s)
+	}
+
+	return f * mult, nil
+}
+
+// Creates the observer from the latitude, longitude and optional height
+// above the ellipsoid, e.g. ["52.23", "21.01", "110"].
+func ParseObserver(args []string) (*orbit.Observer, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, errors.New("expected arguments: latitude longitude [height]")
+	}
+
+	coords := make([]float64, 3)
+
+	for i := range args {
+		c, err := strconv.ParseFloat(args[i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coordinate: %s", args[i])
+		}
+		coords[i] = c
+	}
+
+	return orbit.NewObserver(coords[0], coords[1], coords[2])
+}
+
+// Creates the elements from the state vector at time t: the position [m]
+// and velocity [m/s] in the inertial frame of the body, optionally with
+// k, M or G prefixes, e.g. ["6.8M", "0", "0", "0", "7.6k", "0"].
+func ParseState(args []string, t time.Time, b *orbit.Body) (*orbit.Elements, error) {
+	if len(args) != 6 {
+		return nil, errors.New("expected arguments: x y z vx vy vz")
+	}
+
+	var r, v orbit.Vector
+
+	for i := range args {
+		n, err := ParseNumber(args[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if i < 3 {
+			r[i] = n
+		} else {
+			v[i-3] = n
+		}
+	}
+
+	obj, err := orbit.CalculateElementsFromState(r, v, t.Unix(), b)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.Name = "STATE VECTOR"
+
+	return obj, nil
+}
+
+// Encodes the elements created from the state vector as a three-line set,
+// with the epoch at the time of the elements, and reads it back.
+// The identifiers and drag terms of the set are zero. The lines
+// of the set are copied to the elements.
+func StateToTLE(obj *orbit.Elements) (*tle.TLE, error) {
+	var base tle.TLE
+	base.L1.Epoch.Year, base.L1.Epoch.Day = tle.TimeToEpoch(time.Unix(obj.Time, 0))
+
+	set := obj.ToTLE(&base)
+	set.Match = &tle.Match{Title: obj.Name}
+
+	m, err := tle.EncodeTLE(set)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.L1, obj.L2 = m.Line1, m.Line2
+
+	return tle.ParseMatch(m)
+}
+
+// Returns the body the elements are calculated for: the set of constants
+// if the dominant body is Earth, the body itself otherwise.
+func withConstants(body, consts *orbit.Body) *orbit.Body {
+	if body == orbit.Earth {
+		return consts
+	}
+	return body
+}
+
+// Rewrites the passed slice, omitting duplicate values.
+func RemoveDuplicates(s []string) []string {
+	clean := make([]string, 0, len(s))
+
+	for i := range s {
+		if !Contains(clean, s[i]) {
+			clean = append(clean, s[i])
+		}
+	}
+
+	return clean
+}
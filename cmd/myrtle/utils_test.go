@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests ParseDuration function. The day unit must be accepted alongside
+// the units of time.ParseDuration, and the sign must apply to the whole value.
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"90m":    90 * time.Minute,
+		"+2h":    2 * time.Hour,
+		"-1d12h": -36 * time.Hour,
+		"3d":     72 * time.Hour,
+		"0.5d":   12 * time.Hour,
+	}
+
+	for k, v := range cases {
+		d, err := ParseDuration(k)
+		if err != nil || d != v {
+			t.Fatalf("%s: %v, %v", k, d, err)
+		}
+	}
+
+	for _, s := range []string{"", "+", "d", "1x", "1d1"} {
+		if _, err := ParseDuration(s); err == nil {
+			t.Fatalf("%q: error expected", s)
+		}
+	}
+}
+
+// Tests ParseFrequency function. Metric prefixes and the unit must be
+// accepted, non-positive and malformed values must be rejected.
+func TestParseFrequency(t *testing.T) {
+	cases := map[string]float64{
+		"145800000": 145.8e6,
+		"437.8M":    437.8e6,
+		"437800k":   437.8e6,
+		"2.2GHz":    2.2e9,
+		"145.9e6":   145.9e6,
+	}
+
+	for k, v := range cases {
+		f, err := ParseFrequency(k)
+		if err != nil || math.Abs(f-v) > 1e-3 {
+			t.Fatalf("%s: %f, %v", k, f, err)
+		}
+	}
+
+	for _, s := range []string{"", "M", "-5M", "0", "12x"} {
+		if _, err := ParseFrequency(s); err == nil {
+			t.Fatalf("%q: error expected", s)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package orbit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Body describes the dominant body of the orbit.
+type Body struct {
+	// Name of the body
+	Name string
+
+	// Name of the set of constants, empty if there is only one
+	Constants string
+
+	// Standard gravitational parameter [m^3/s^2]
+	GM float64
+
+	// Mean radius
+	R float64
+
+	// Equatorial radius
+	A float64
+
+	// Flattening of the reference ellipsoid
+	F float64
+
+	// Second zonal harmonic of the gravity field
+	J2 float64
+}
+
+// Returns the mass of the body.
+func (b *Body) Mass() float64 {
+	return b.GM / G
+}
+
+// Sets of geodetic and gravitational constants of Earth. WGS-72 is the one
+// the element sets are generated with and it is used by SGP4.
+var (
+	WGS72 = &Body{Name: "Earth", Constants: "WGS-72", GM: sgp4Mu * 1e9, R: R_E, A: sgp4Radius * 1e3, F: 1 / 298.26, J2: sgp4J2}
+	WGS84 = &Body{Name: "Earth", Constants: "WGS-84", GM: 3.986004418e+14, R: R_E, A: WGS84_A, F: WGS84_F, J2: 1.08262998905e-3}
+	EGM96 = &Body{Name: "Earth", Constants: "EGM-96", GM: 3.986004415e+14, R: R_E, A: 6.3781363e+6, F: WGS84_F, J2: 1.08262668e-3}
+)
+
+// Catalogue of the sets of constants of Earth.
+var ConstantSets = []*Body{WGS72, WGS84, EGM96}
+
+// Dominant bodies. The values of the gravitational parameters
+// and the radii of the bodies other than Earth follow the IAU
+// and NASA fact sheets.
+var (
+	Earth = WGS72
+	Moon  = &Body{Name: "Moon", GM: 4.9028001e+12, R: 1.7374e+6, A: 1.7381e+6, F: 0.0012, J2: 2.0330e-4}
+	Mars  = &Body{Name: "Mars", GM: 4.2828372e+13, R: 3.3895e+6, A: 3.39619e+6, F: 0.00589, J2: 1.96045e-3}
+	Sun   = &Body{Name: "Sun", GM: 1.32712440018e+20, R: 6.957e+8, A: 6.957e+8, F: 9e-6, J2: 2.2e-7}
+)
+
+// Catalogue of the dominant bodies.
+var Bodies = []*Body{Earth, Moon, Mars, Sun}
+
+// Returns the body from the catalogue given its name, regardless
+// of the letter case.
+func FindBody(name string) (*Body, error) {
+	for _, b := range Bodies {
+		if strings.EqualFold(b.Name, name) {
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown body: %s", name)
+}
+
+// Returns the set of constants of Earth given its name, regardless
+// of the letter case and the hyphen, e.g. wgs84 or WGS-84.
+func FindConstants(name string) (*Body, error) {
+	for _, b := range ConstantSets {
+		if strings.EqualFold(strings.ReplaceAll(b.Constants, "-", ""), strings.ReplaceAll(name, "-", "")) {
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown set of constants: %s", name)
+}
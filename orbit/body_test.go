@@ -0,0 +1,82 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests FindBody, FindConstants and CalculateElementsAround functions.
+// The bodies and the sets of constants must be found regardless of the letter
+// case, and the semi-major axis must satisfy Kepler's third law with
+// the gravitational parameter of the body.
+func TestBodies(t *testing.T) {
+	for _, name := range []string{"earth", "MOON", "Mars", "sun"} {
+		if _, err := FindBody(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := FindBody("vulcan"); err == nil {
+		t.Fatal("Unknown body found")
+	}
+
+	for _, name := range []string{"wgs72", "WGS-84", "egm-96"} {
+		if _, err := FindConstants(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := FindConstants("wgs-60"); err == nil {
+		t.Fatal("Unknown set of constants found")
+	}
+
+	set := testISS(t)
+
+	for _, b := range append(Bodies, ConstantSets...) {
+		e := CalculateElementsAround(set, b)
+
+		n := 2 * math.Pi / e.T
+		if gm := n * n * math.Pow(e.SMa, 3); math.Abs(gm-b.GM)/b.GM > 1e-9 {
+			t.Fatalf("%s: GM %g, expected %g", b.Name, gm, b.GM)
+		}
+
+		if e.Body != b.Name || e.Constants != b.Constants || e.DR != b.R {
+			t.Fatalf("%s: improper body %q %q, radius %f", b.Name, e.Body, e.Constants, e.DR)
+		}
+
+		// Mean motion is Kozai only around Earth
+		if kozai := e.SMa != e.KSMa; kozai != (b.Name == Earth.Name) {
+			t.Fatalf("%s: SMa %f, Kozai SMa %f", b.Name, e.SMa, e.KSMa)
+		}
+	}
+}
+
+// Tests the altitudes above the ellipsoid. Periapsis over the equator
+// is measured from the equatorial radius, periapsis over the pole
+// from the polar one. The altitudes of the elements calculated
+// for the mass and radius are measured above the sphere.
+func TestEllipsoidAltitude(t *testing.T) {
+	const tolerance float64 = 1e-6
+
+	set := testISS(t)
+
+	sphere := CalculateElements(set, M_E, R_E)
+	if math.Abs(sphere.PeA-(sphere.PeR-R_E)) > tolerance || math.Abs(sphere.ApA-(sphere.ApR-R_E)) > tolerance {
+		t.Fatalf("Sphere: PeA %f, ApA %f", sphere.PeA, sphere.ApA)
+	}
+
+	e := CalculateElementsAround(set, WGS84)
+
+	e.Set("Inc", 0)
+	if math.Abs(e.PeA-(e.PeR-WGS84_A)) > tolerance || math.Abs(e.ApA-(e.ApR-WGS84_A)) > tolerance {
+		t.Fatalf("Equator: PeA %f, ApA %f", e.PeA, e.ApA)
+	}
+
+	e.Set("Inc", 90)
+	e.Set("AgP", 90)
+
+	polar := WGS84_A * (1 - WGS84_F)
+	if math.Abs(e.PeA-(e.PeR-polar)) > tolerance || math.Abs(e.ApA-(e.ApR-polar)) > tolerance {
+		t.Fatalf("Pole: PeA %f, ApA %f", e.PeA, e.ApA)
+	}
+}
@@ -14,12 +14,11 @@
 // http://murison.alpheratz.net/dynamics/twobody/KeplerIterations_summary.pdf
 // [accessed on 15.01.2022] U.S. Naval Observatory, Washington, DC.
 
-package main
+package orbit
 
 import (
 	"errors"
 	"math"
-	"time"
 )
 
 const (
@@ -42,6 +41,50 @@ func Period(mnm float64) float64 {
 	return 86400 / mnm
 }
 
+// Recovers the original (Brouwer) mean motion from the Kozai mean motion
+// of TLE, the way SGP4 initialization does. The Kozai mean motion includes
+// the effects of J2, so it does not yield the proper semi-major axis
+// by Kepler's third law. Accepts mean motion [rev/day], eccentricity,
+// inclination, and the gravitational parameter, equatorial radius and second
+// zonal harmonic of the dominant body. If J2 is zero, mean motion
+// is returned unchanged.
+func BrouwerMeanMotion(mnm, ecc, inc, gm, a, j2 float64) float64 {
+	if j2 == 0 {
+		return mnm
+	}
+
+	// Square root of the gravitational parameter in body radii^1.5/min
+	xke := 60 / math.Sqrt(a*a*a/gm)
+
+	no := unKozai(mnm*2*math.Pi/1440, ecc, math.Cos(Rad(inc)), xke, j2)
+	return no * 1440 / (2 * math.Pi)
+}
+
+// Returns the Kozai mean motion of TLE given the original (Brouwer) one,
+// the inverse of BrouwerMeanMotion. The arguments are the same.
+func KozaiMeanMotion(mnm, ecc, inc, gm, a, j2 float64) float64 {
+	const (
+		maxIter   int     = 20
+		tolerance float64 = 1.0e-15
+	)
+
+	if j2 == 0 {
+		return mnm
+	}
+
+	kozai := mnm
+
+	for i := 0; i < maxIter; i++ {
+		next := kozai * mnm / BrouwerMeanMotion(kozai, ecc, inc, gm, a, j2)
+		if math.Abs(next-kozai) <= tolerance*mnm {
+			return next
+		}
+		kozai = next
+	}
+
+	return kozai
+}
+
 // Calculates semi-major axis from orbital period and dominant body mass.
 func SemiMajorAxis(t, dominantMass float64) float64 {
 	return math.Cbrt((G * dominantMass * math.Pow(t, 2)) / (4 * math.Pow(math.Pi, 2)))
@@ -158,12 +201,32 @@ func TrueAnomaly(ecc, eca float64) float64 {
 	return 2 * Deg(math.Atan2(math.Sqrt(1+ecc)*math.Sin(ecaRad/2), math.Sqrt(1-ecc)*math.Cos(ecaRad/2)))
 }
 
+// Calculates mean anomaly from orbital eccentricity and true anomaly.
+func MeanAnomaly(ecc, tra float64) float64 {
+	sin, cos := math.Sincos(Rad(tra))
+	eca := math.Atan2(math.Sqrt(1-ecc*ecc)*sin, ecc+cos)
+
+	return normalizeAngle(Deg(eca - ecc*math.Sin(eca)))
+}
+
 // Returns orbital radius given semi-major axis, orbital eccentricity
 // and true anomaly.
 func OrbitalRadius(sma, ecc, tra float64) float64 {
 	return sma * ((1 - math.Pow(ecc, 2)) / (1 + ecc*math.Cos(Rad(tra))))
 }
 
+// Returns the altitude above the ellipsoid, given by its equatorial radius
+// and flattening, of the point in orbit at radius r and argument of latitude
+// aol, the angle from the ascending node. The altitude does not depend
+// on the longitude, so it is found in the meridian plane of the point.
+func EllipsoidAltitude(r, inc, aol, a, f float64) float64 {
+	sinLat := math.Sin(Rad(inc)) * math.Sin(Rad(aol))
+	cosLat := math.Sqrt(1 - sinLat*sinLat)
+
+	_, _, hgt := bodyToGeodetic(Vector{r * cosLat, 0, r * sinLat}, a, f)
+	return hgt
+}
+
 // Calculates orbital velocity from semi-major axis, orbital radius
 // and dominant body mass.
 func OrbitalVelocity(r, sma, dominantMass float64) float64 {
@@ -175,12 +238,6 @@ func TrueLongitude(tra, lpe float64) float64 {
 	return math.Mod(tra+lpe, 360)
 }
 
-// Converts epoch year and fraction of a day extracted from TLE
-// to unix time in seconds.
-func EpochToUnix(epochYear int, epochDay float64) int64 {
-	return time.Date(epochYear, time.January, 0, 0, 0, 0, 0, time.UTC).Unix() + int64(86400*epochDay)
-}
-
 // Calculates the average rate of sweep from orbital period [deg/sec].
 func sweepRate(t float64) float64 {
 	return 360 / t
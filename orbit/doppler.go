@@ -0,0 +1,114 @@
+package orbit
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Speed of light in vacuum [m/s]
+const C float64 = 299792458
+
+// DopplerSample holds the Doppler-corrected frequency at a given time.
+type DopplerSample struct {
+	Time time.Time
+
+	// Elevation of the object above the observer's horizon
+	El float64
+
+	// Range rate, positive if the object recedes
+	RRt float64
+
+	// Corrected frequency [Hz]
+	Freq float64
+
+	// Difference between the corrected and the nominal frequency [Hz]
+	Shift float64
+
+	// Rate of change of the corrected frequency [Hz/s]
+	Rate float64
+}
+
+// Returns the frequency received by the observer if the object transmits
+// at frequency f and moves away at range rate rrt.
+func DopplerDownlink(f, rrt float64) float64 {
+	return f * (1 - rrt/C)
+}
+
+// Returns the frequency the observer should transmit at, so that the object
+// moving away at range rate rrt receives frequency f.
+func DopplerUplink(f, rrt float64) float64 {
+	return f / (1 - rrt/C)
+}
+
+// Computes the Doppler-corrected frequency for nominal frequency f between
+// start and end, with samples spaced by step. If uplink is true, the uplink
+// correction is applied, the downlink one otherwise. If the propagation
+// fails, the samples computed so far are returned along with the error.
+func DopplerTable(s *SGP4, obs *Observer, start, end time.Time, step time.Duration, f float64, uplink bool) ([]*DopplerSample, error) {
+	if step <= 0 {
+		return nil, errStep
+	}
+
+	correct := DopplerDownlink
+	if uplink {
+		correct = DopplerUplink
+	}
+
+	// Half of the interval over which the rate of change is computed
+	const h = 500 * time.Millisecond
+
+	table := make([]*DopplerSample, 0, int(end.Sub(start)/step)+1)
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		l, err := obs.Look(s, t)
+		if err != nil {
+			return table, err
+		}
+
+		before, err := obs.Look(s, t.Add(-h))
+		if err != nil {
+			return table, err
+		}
+
+		after, err := obs.Look(s, t.Add(h))
+		if err != nil {
+			return table, err
+		}
+
+		var ds DopplerSample
+
+		ds.Time = t
+		ds.El = l.El
+		ds.RRt = l.RRt
+		ds.Freq = correct(f, l.RRt)
+		ds.Shift = ds.Freq - f
+		ds.Rate = (correct(f, after.RRt) - correct(f, before.RRt)) / (2 * h).Seconds()
+
+		table = append(table, &ds)
+	}
+
+	return table, nil
+}
+
+// Writes the Doppler table in CSV format, preceded by the header row.
+func WriteDopplerCSV(w io.Writer, table []*DopplerSample) error {
+	cw := csv.NewWriter(w)
+
+	cw.Write([]string{"time", "elevation_deg", "range_rate_m_s", "frequency_hz", "shift_hz", "rate_hz_s"})
+
+	for _, ds := range table {
+		cw.Write([]string{
+			ds.Time.UTC().Format("2006-01-02T15:04:05.000Z"),
+			strconv.FormatFloat(ds.El, 'f', 3, 64),
+			strconv.FormatFloat(ds.RRt, 'f', 3, 64),
+			strconv.FormatFloat(ds.Freq, 'f', 1, 64),
+			strconv.FormatFloat(ds.Shift, 'f', 1, 64),
+			strconv.FormatFloat(ds.Rate, 'f', 3, 64),
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
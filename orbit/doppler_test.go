@@ -0,0 +1,84 @@
+package orbit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests DopplerDownlink and DopplerUplink functions. The received frequency
+// must drop as the object recedes, and the uplink correction must shift
+// the frequency in the opposite direction.
+func TestDopplerCorrection(t *testing.T) {
+	const f float64 = 437.8e6
+
+	for _, rrt := range []float64{-7000, -100, 100, 7000} {
+		down := DopplerDownlink(f, rrt) - f
+		up := DopplerUplink(f, rrt) - f
+
+		if math.Signbit(down) != (rrt > 0) {
+			t.Fatalf("%g: downlink shift of wrong sign: %f", rrt, down)
+		}
+
+		if math.Signbit(down) == math.Signbit(up) {
+			t.Fatalf("%g: shifts of the same sign: %f, %f", rrt, down, up)
+		}
+	}
+
+	if DopplerDownlink(f, 0) != f || DopplerUplink(f, 0) != f {
+		t.Fatal("Shift expected to be zero for stationary object")
+	}
+}
+
+// Tests DopplerTable and WriteDopplerCSV functions over a single ISS pass.
+// The shift must change sign as the object approaches and then recedes,
+// and the CSV output must contain the header row and one row per sample.
+func TestDopplerTable(t *testing.T) {
+	obs, err := NewObserver(52.23, 21.01, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := testISSProp(t)
+
+	passes, err := FindPasses(s, obs, s.Epoch(), s.Epoch().Add(24*time.Hour))
+	if err != nil || len(passes) == 0 {
+		t.Fatalf("No pass found: %v", err)
+	}
+
+	p := passes[0]
+
+	table, err := DopplerTable(s, obs, p.AOS, p.LOS, time.Second, 437.8e6, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, last := table[0], table[len(table)-1]
+
+	if first.Shift <= 0 || last.Shift >= 0 {
+		t.Fatalf("Unexpected shift at AOS and LOS: %f, %f", first.Shift, last.Shift)
+	}
+
+	for i, ds := range table {
+		if ds.Rate > 0 {
+			t.Fatalf("Sample %d: frequency expected to decrease: %f Hz/s", i, ds.Rate)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := WriteDopplerCSV(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != len(table)+1 || rows[0][0] != "time" {
+		t.Fatalf("Unexpected CSV output: %d rows, header %v", len(rows), rows[0])
+	}
+}
@@ -0,0 +1,355 @@
+// Package orbit calculates orbital elements and propagates TLE sets
+// with SGP4/SDP4 to obtain positions relative to Earth and the observer.
+package orbit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Elements struct holding orbital parameters derived from TLE.
+type Elements struct {
+	// The name of the described object
+	Name,
+
+	// Trimmed TLE lines
+	L1 string
+	L2 string
+
+	// TLE fields that could not be parsed
+	Warnings []*tle.TLEError
+
+	// Epoch in unix seconds
+	Epoch int64
+
+	// Time at which the elements are evaluated in unix seconds
+	Time int64
+
+	// Name of dominant body, empty if not known
+	Body string
+
+	// Name of the set of constants of dominant body, empty if not known
+	Constants string
+
+	// Mass of dominant body
+	DM float64
+
+	// Radius of dominant body
+	DR float64
+
+	// Equatorial radius of dominant body's reference ellipsoid
+	DA float64
+
+	// Flattening of dominant body's reference ellipsoid
+	DF float64
+
+	// Second zonal harmonic of dominant body's gravity field
+	DJ2 float64
+
+	// Semi-Major Axis
+	SMa float64
+
+	// Semi-Major Axis derived from the Kozai mean motion of TLE
+	KSMa float64
+
+	// Semi-Minor Axis
+	SMi float64
+
+	// Periapsis Radius
+	PeR float64
+
+	// Apoapsis Radius
+	ApR float64
+
+	// Periapsis Altitude above the reference ellipsoid
+	PeA float64
+
+	// Apoapsis Altitude above the reference ellipsoid
+	ApA float64
+
+	// Radius at Epoch
+	R float64
+
+	// Altitude at Epoch above the reference ellipsoid
+	Alt float64
+
+	// Orbital Eccenticity
+	Ecc float64
+
+	// Orbital Period
+	T float64
+
+	// Orbital Period derived from the Kozai mean motion of TLE
+	KT float64
+
+	// Time to Periapsis
+	PeT float64
+
+	// Time to Apoapsis
+	ApT float64
+
+	// Velocity at Epoch
+	Vel float64
+
+	// Orbital Inclination
+	Inc float64
+
+	// Longitude of Ascending Node
+	LAN float64
+
+	// Longitude of Periapsis
+	LPe float64
+
+	// Argument of Periapsis
+	AgP float64
+
+	// True Anomaly
+	TrA float64
+
+	// True Longitude
+	TrL float64
+
+	// Mean Anomaly
+	MnA float64
+
+	// Mean Longitude
+	MnL float64
+
+	// Eccentric Anomaly
+	EcA float64
+
+	// Indicates that eccentric anomaly solution did not converge
+	EcAConvErr bool
+}
+
+// Returns a copy of the elements advanced to time t [unix seconds].
+// The longitude of ascending node, argument of periapsis and mean anomaly
+// drift at the secular rates caused by the oblateness of the dominant body,
+// and all the values depending on them are recalculated. Drag and
+// the periodic perturbations are not modelled, so the elements slowly
+// depart from the state propagated by SGP4.
+func (e *Elements) AdvanceTo(t int64) *Elements {
+	adv := *e
+
+	dt := float64(t - e.Time)
+	lanRate, agpRate, mnaRate := e.SecularRates()
+
+	adv.Time = t
+	adv.LAN = normalizeAngle(e.LAN + lanRate*dt)
+	adv.AgP = normalizeAngle(e.AgP + agpRate*dt)
+	adv.MnA = normalizeAngle(e.MnA + mnaRate*dt)
+
+	adv.calculateShape()
+	adv.calculateAnomalies()
+
+	return &adv
+}
+
+// Returns the secular rates of the longitude of ascending node, argument
+// of periapsis and mean anomaly [deg/s] caused by the second zonal harmonic
+// (J2) of the dominant body. These are the first-order rates SGP4 applies
+// to the Brouwer mean motion. If J2 is zero, the node and periapsis are fixed
+// and the mean anomaly advances at the mean motion.
+func (e *Elements) SecularRates() (lan, agp, mna float64) {
+	n := sweepRate(e.T)
+
+	p := e.SMa * (1 - e.Ecc*e.Ecc)
+	k := 0.75 * e.DJ2 * (e.DA / p) * (e.DA / p) * n
+	cos := math.Cos(Rad(e.Inc))
+
+	lan = -2 * k * cos
+	agp = k * (5*cos*cos - 1)
+	mna = n + k*math.Sqrt(1-e.Ecc*e.Ecc)*(3*cos*cos-1)
+
+	return lan, agp, mna
+}
+
+// Calculates the values that depend on the mean anomaly.
+func (e *Elements) calculateAnomalies() {
+	var err error
+
+	e.PeT = TimeToPeriapsis(e.MnA, e.T)
+	e.ApT = TimeToApoapsis(e.MnA, e.T, e.PeT)
+
+	e.MnL = MeanLongitude(e.MnA, e.LPe)
+
+	e.EcA, err = EccentricAnomaly(e.Ecc, e.MnA)
+	e.EcAConvErr = err != nil
+
+	e.TrA = TrueAnomaly(e.Ecc, e.EcA)
+	e.TrL = TrueLongitude(e.TrA, e.LPe)
+	e.R = OrbitalRadius(e.SMa, e.Ecc, e.TrA)
+	e.Alt = EllipsoidAltitude(e.R, e.Inc, e.AgP+e.TrA, e.DA, e.DF)
+	e.Vel = OrbitalVelocity(e.R, e.SMa, e.DM)
+}
+
+// Creates Elements struct from TLE. Accepts dominant body mass and radius as well.
+// The altitudes are measured above the sphere of that radius.
+func CalculateElements(set *tle.TLE, m, r float64) *Elements {
+	return calculateElements(set, &Body{GM: G * m, R: r, A: r})
+}
+
+// Creates Elements struct from TLE for the orbit around the body. The elements
+// are calculated with the body's gravitational parameter and the altitudes
+// are measured above its reference ellipsoid.
+func CalculateElementsAround(set *tle.TLE, b *Body) *Elements {
+	return calculateElements(set, b)
+}
+
+// Creates Elements struct from TLE for the orbit around the body.
+func calculateElements(set *tle.TLE, b *Body) *Elements {
+	var e Elements
+
+	e.Name = strings.Trim(set.Match.Title, " ")
+	e.L1 = strings.Trim(set.Match.Line1, " ")
+	e.L2 = strings.Trim(set.Match.Line2, " ")
+	e.Warnings = set.Warnings
+
+	// OMM carries no lines, the identifiers are displayed instead
+	if set.Match.OMM != nil {
+		id := set.L1.IntlDesig
+		e.L1 = "NORAD CAT ID " + set.L1.CatNum
+		e.L2 = fmt.Sprintf("INTL DESIGNATOR %d-%03d%s", id.LaunchYear, id.LaunchNum, id.LaunchComp)
+	}
+
+	e.Epoch = tle.EpochToUnix(set.L1.Epoch.Year, set.L1.Epoch.Day)
+	e.Time = e.Epoch
+
+	e.Body = b.Name
+	e.Constants = b.Constants
+
+	e.DM = b.Mass()
+	e.DR = b.R
+	e.DA = b.A
+	e.DF = b.F
+	e.DJ2 = b.J2
+
+	e.Ecc = set.L2.Ecc
+	e.Inc = set.L2.Inc
+	e.LAN = set.L2.LAN
+	e.AgP = set.L2.AgP
+	e.MnA = set.L2.MnA
+
+	// TLE carries the Kozai mean motion, the Brouwer one satisfies Kepler's third law
+	e.T = Period(BrouwerMeanMotion(set.L2.MnM, e.Ecc, e.Inc, G*e.DM, e.DA, e.kozaiJ2()))
+	e.SMa = SemiMajorAxis(e.T, e.DM)
+
+	e.calculateShape()
+	e.calculateAnomalies()
+
+	return &e
+}
+
+// Calculates the values that depend on the semi-major axis, eccentricity
+// and orientation of the orbit.
+func (e *Elements) calculateShape() {
+	e.KT = Period(KozaiMeanMotion(86400/e.T, e.Ecc, e.Inc, G*e.DM, e.DA, e.kozaiJ2()))
+	e.KSMa = SemiMajorAxis(e.KT, e.DM)
+
+	e.SMi = SemiMinorAxis(e.SMa, e.Ecc)
+	e.PeR = PeriapsisRadius(e.SMa, e.Ecc)
+	e.ApR = ApoapsisRadius(e.SMa, e.Ecc)
+
+	e.PeA = EllipsoidAltitude(e.PeR, e.Inc, e.AgP, e.DA, e.DF)
+	e.ApA = EllipsoidAltitude(e.ApR, e.Inc, e.AgP+180, e.DA, e.DF)
+
+	e.LPe = LongitudeOfPeriapsis(e.LAN, e.AgP)
+}
+
+// Returns J2 used to convert between the Kozai and Brouwer mean motion.
+// The mean motion is Kozai only in the sets fitted for SGP4, which models
+// the orbits around Earth. Around the other bodies, the mean motion of TLE
+// is taken as Keplerian, so zero is returned.
+func (e *Elements) kozaiJ2() float64 {
+	if e.Body != Earth.Name {
+		return 0
+	}
+	return e.DJ2
+}
+
+// Sets the value of the element given by its symbol and recalculates
+// the dependent values. Only the elements that define the orbit can be set:
+// SMa or T, Ecc, Inc, LAN, AgP and MnA. Angles are normalized.
+func (e *Elements) Set(symbol string, value float64) error {
+	switch symbol {
+	case "SMa", "T":
+		if value <= 0 {
+			return fmt.Errorf("%s must be positive: %g", symbol, value)
+		}
+
+		if symbol == "SMa" {
+			e.SMa = value
+			e.T = 2 * math.Pi * math.Sqrt(math.Pow(value, 3)/(G*e.DM))
+		} else {
+			e.T = value
+			e.SMa = SemiMajorAxis(value, e.DM)
+		}
+	case "Ecc":
+		if value < 0 || value >= 1 {
+			return fmt.Errorf("Ecc out of range <0;1): %g", value)
+		}
+		e.Ecc = value
+	case "Inc":
+		if value < 0 || value > 180 {
+			return fmt.Errorf("Inc out of range <0;180>: %g", value)
+		}
+		e.Inc = value
+	case "LAN":
+		e.LAN = normalizeAngle(value)
+	case "AgP":
+		e.AgP = normalizeAngle(value)
+	case "MnA":
+		e.MnA = normalizeAngle(value)
+	default:
+		return fmt.Errorf("element cannot be set: %s", symbol)
+	}
+
+	e.calculateShape()
+	e.calculateAnomalies()
+
+	return nil
+}
+
+// Returns the number of ascending node passages between the elements of base
+// and these ones, which is negative if the elements precede base. The turns
+// completed between them are resolved with the argument of latitude advanced
+// at the secular rates.
+func (e *Elements) nodePassages(base *tle.TLE) int {
+	eca, _ := EccentricAnomaly(base.L2.Ecc, base.L2.MnA)
+
+	start := normalizeAngle(base.L2.AgP + TrueAnomaly(base.L2.Ecc, eca))
+	end := normalizeAngle(e.AgP + e.TrA)
+
+	_, agpRate, mnaRate := e.SecularRates()
+	advance := (agpRate + mnaRate) * float64(e.Time-e.Epoch)
+
+	return int(math.Round((start + advance - end) / 360))
+}
+
+// Creates the TLE struct describing the elements. The identifiers, drag terms
+// and the remaining values are copied from base. The epoch is set to the time
+// at which the elements are evaluated.
+func (e *Elements) ToTLE(base *tle.TLE) *tle.TLE {
+	set := *base
+	set.Warnings = nil
+
+	// Epoch in unix seconds is truncated, the original one is preserved if possible
+	if e.Time != e.Epoch {
+		set.L1.Epoch.Year, set.L1.Epoch.Day = tle.TimeToEpoch(time.Unix(e.Time, 0))
+	}
+
+	set.L2.Ecc = e.Ecc
+	set.L2.Inc = e.Inc
+	set.L2.LAN = e.LAN
+	set.L2.AgP = e.AgP
+	set.L2.MnA = e.MnA
+	set.L2.MnM = 86400 / e.KT
+
+	set.L2.RevN += e.nodePassages(base)
+
+	return &set
+}
@@ -0,0 +1,144 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Tests AdvanceTo method. A day after the epoch, the node of ISS must have
+// regressed by about 5 degrees, and both the node and the argument
+// of latitude must agree with the state propagated by SGP4 within
+// the periodic perturbations. The epoch must be retained.
+func TestAdvanceTo(t *testing.T) {
+	const tolerance float64 = 0.2
+
+	e := CalculateElementsAround(testISS(t), Earth)
+	s := testISSProp(t)
+
+	adv := e.AdvanceTo(e.Epoch + 86400)
+	if adv.Time != e.Epoch+86400 || adv.Epoch != e.Epoch {
+		t.Fatalf("Improper time stamps: %d, %d", adv.Time, adv.Epoch)
+	}
+
+	if drift := math.Remainder(adv.LAN-e.LAN, 360); drift < -5.3 || drift > -4.7 {
+		t.Fatalf("LAN drift %f deg/day, expected about -5", drift)
+	}
+
+	r, v, err := s.Propagate(time.Unix(adv.Time, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Node and argument of latitude of the propagated state
+	h := Vector{r[1]*v[2] - r[2]*v[1], r[2]*v[0] - r[0]*v[2], r[0]*v[1] - r[1]*v[0]}
+	lan := math.Atan2(h[0], -h[1])
+	u := math.Atan2(r[2]/math.Sin(Rad(adv.Inc)), r[0]*math.Cos(lan)+r[1]*math.Sin(lan))
+
+	if d := math.Remainder(adv.LAN-Deg(lan), 360); math.Abs(d) > tolerance {
+		t.Fatalf("LAN %f, SGP4 %f", adv.LAN, Deg(lan))
+	}
+
+	if d := math.Remainder(adv.AgP+adv.TrA-Deg(u), 360); math.Abs(d) > tolerance {
+		t.Fatalf("Argument of latitude %f, SGP4 %f", adv.AgP+adv.TrA, Deg(u))
+	}
+}
+
+// Tests Elements.Set and Elements.ToTLE methods. The edited elements must
+// survive encoding into TLE and parsing it back, within the precision
+// of the format.
+func TestSetElements(t *testing.T) {
+	set := testISS(t)
+
+	e := CalculateElements(set, M_E, R_E)
+
+	edits := map[string]float64{"SMa": 6.8e6, "Ecc": 0.01, "Inc": 97.5, "LAN": -10, "AgP": 370, "MnA": 45}
+
+	for k, v := range edits {
+		if err := e.Set(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for k, v := range map[string]float64{"Ecc": 1, "Inc": -1, "SMa": 0, "TrA": 10} {
+		if err := e.Set(k, v); err == nil {
+			t.Fatalf("%s = %g: error expected", k, v)
+		}
+	}
+
+	out, err := tle.EncodeTLE(e.ToTLE(set))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := tle.ParseMatch(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := CalculateElements(parsed, M_E, R_E)
+
+	pass := math.Abs(r.SMa-6.8e6) < 1 &&
+		r.Ecc == 0.01 &&
+		r.Inc == 97.5 &&
+		r.LAN == 350 &&
+		r.AgP == 10 &&
+		r.MnA == 45 &&
+		r.Epoch == e.Epoch
+
+	if !pass {
+		t.Fatalf("Unexpected elements:\n%s\n%s", out.Line1, out.Line2)
+	}
+}
+
+// Tests Elements.ToTLE method with the advanced elements. The revolution
+// number must grow by the passages of the ascending node only, which depend
+// on the position of the object at the epoch, not just on the elapsed time.
+func TestToTLERevN(t *testing.T) {
+	set := testISS(t)
+
+	e := CalculateElementsAround(set, Earth)
+
+	// The argument of latitude of ISS is about 28 degrees at the epoch
+	cases := map[float64]int{0.9: 0, 0.95: 1, -0.05: 0, -0.1: -1, 10: 10}
+
+	for periods, want := range cases {
+		adv := e.AdvanceTo(e.Epoch + int64(periods*e.T))
+
+		if revs := adv.ToTLE(set).L2.RevN - set.L2.RevN; revs != want {
+			t.Fatalf("%g periods: %d revolutions, expected %d", periods, revs, want)
+		}
+	}
+}
+
+// Tests BrouwerMeanMotion and KozaiMeanMotion functions. The recovered
+// mean motion must match the one SGP4 is initialized with, the Kozai one
+// must be restored from it and written back to the set unchanged.
+func TestBrouwerMeanMotion(t *testing.T) {
+	const tolerance float64 = 1e-12
+
+	set := testISS(t)
+	prop := testISSProp(t)
+
+	mnm := BrouwerMeanMotion(set.L2.MnM, set.L2.Ecc, set.L2.Inc, WGS72.GM, WGS72.A, WGS72.J2)
+	if no := mnm * 2 * math.Pi / 1440; math.Abs(no-prop.no) > tolerance {
+		t.Fatalf("Brouwer mean motion %.15f rad/min, SGP4 %.15f", no, prop.no)
+	}
+
+	if kozai := KozaiMeanMotion(mnm, set.L2.Ecc, set.L2.Inc, WGS72.GM, WGS72.A, WGS72.J2); math.Abs(kozai-set.L2.MnM) > tolerance {
+		t.Fatalf("Kozai mean motion %.15f, expected %.15f", kozai, set.L2.MnM)
+	}
+
+	e := CalculateElementsAround(set, WGS72)
+
+	// The Brouwer mean motion of ISS is lower, raising the orbit by about 500 m
+	if d := e.SMa - e.KSMa; d < 400 || d > 600 {
+		t.Fatalf("SMa %f, Kozai SMa %f", e.SMa, e.KSMa)
+	}
+
+	if mnm := e.ToTLE(set).L2.MnM; math.Abs(mnm-set.L2.MnM) > tolerance {
+		t.Fatalf("ToTLE: mean motion %.15f, expected %.15f", mnm, set.L2.MnM)
+	}
+}
@@ -0,0 +1,193 @@
+package orbit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Machine-readable representation of Elements. The names of the fields
+// carry the units: m, s, m/s, kg and degrees. Times are given in RFC 3339
+// format. The state vectors are included in the perifocal and inertial
+// frames.
+type elementsRecord struct {
+	Name  string `json:"name"`
+	Line1 string `json:"line1"`
+	Line2 string `json:"line2"`
+
+	Epoch string `json:"epoch"`
+	Time  string `json:"time"`
+
+	Body      string  `json:"dominant_body"`
+	Constants string  `json:"constants"`
+	DM        float64 `json:"dominant_body_mass_kg"`
+	DR        float64 `json:"dominant_body_radius_m"`
+	DA        float64 `json:"dominant_body_equatorial_radius_m"`
+	DF        float64 `json:"dominant_body_flattening"`
+	DJ2       float64 `json:"dominant_body_j2"`
+
+	SMa  float64 `json:"semi_major_axis_m"`
+	KSMa float64 `json:"kozai_semi_major_axis_m"`
+	SMi  float64 `json:"semi_minor_axis_m"`
+	PeR  float64 `json:"periapsis_radius_m"`
+	ApR  float64 `json:"apoapsis_radius_m"`
+	PeA  float64 `json:"periapsis_altitude_m"`
+	ApA  float64 `json:"apoapsis_altitude_m"`
+	R    float64 `json:"radius_m"`
+	Alt  float64 `json:"altitude_m"`
+	Ecc  float64 `json:"eccentricity"`
+	T    float64 `json:"period_s"`
+	KT   float64 `json:"kozai_period_s"`
+	PeT  float64 `json:"time_to_periapsis_s"`
+	ApT  float64 `json:"time_to_apoapsis_s"`
+	Vel  float64 `json:"velocity_m_s"`
+
+	Inc float64 `json:"inclination_deg"`
+	LAN float64 `json:"longitude_of_ascending_node_deg"`
+	LPe float64 `json:"longitude_of_periapsis_deg"`
+	AgP float64 `json:"argument_of_periapsis_deg"`
+	TrA float64 `json:"true_anomaly_deg"`
+	TrL float64 `json:"true_longitude_deg"`
+	MnA float64 `json:"mean_anomaly_deg"`
+	MnL float64 `json:"mean_longitude_deg"`
+	EcA float64 `json:"eccentric_anomaly_deg"`
+
+	EcAConverged bool `json:"eccentric_anomaly_converged"`
+
+	PfRX  float64 `json:"perifocal_position_x_m"`
+	PfRY  float64 `json:"perifocal_position_y_m"`
+	PfRZ  float64 `json:"perifocal_position_z_m"`
+	PfVX  float64 `json:"perifocal_velocity_x_m_s"`
+	PfVY  float64 `json:"perifocal_velocity_y_m_s"`
+	PfVZ  float64 `json:"perifocal_velocity_z_m_s"`
+	EciRX float64 `json:"inertial_position_x_m"`
+	EciRY float64 `json:"inertial_position_y_m"`
+	EciRZ float64 `json:"inertial_position_z_m"`
+	EciVX float64 `json:"inertial_velocity_x_m_s"`
+	EciVY float64 `json:"inertial_velocity_y_m_s"`
+	EciVZ float64 `json:"inertial_velocity_z_m_s"`
+
+	Warnings []string `json:"warnings"`
+}
+
+// Creates the record describing the elements.
+func newElementsRecord(e *Elements) *elementsRecord {
+	const layout = "2006-01-02T15:04:05Z"
+
+	r := elementsRecord{
+		Name:         e.Name,
+		Line1:        e.L1,
+		Line2:        e.L2,
+		Epoch:        time.Unix(e.Epoch, 0).UTC().Format(layout),
+		Time:         time.Unix(e.Time, 0).UTC().Format(layout),
+		Body:         e.Body,
+		DM:           e.DM,
+		Constants:    e.Constants,
+		DR:           e.DR,
+		DA:           e.DA,
+		DF:           e.DF,
+		DJ2:          e.DJ2,
+		SMa:          e.SMa,
+		KSMa:         e.KSMa,
+		SMi:          e.SMi,
+		PeR:          e.PeR,
+		ApR:          e.ApR,
+		PeA:          e.PeA,
+		ApA:          e.ApA,
+		R:            e.R,
+		Alt:          e.Alt,
+		Ecc:          e.Ecc,
+		T:            e.T,
+		KT:           e.KT,
+		PeT:          e.PeT,
+		ApT:          e.ApT,
+		Vel:          e.Vel,
+		Inc:          e.Inc,
+		LAN:          e.LAN,
+		LPe:          e.LPe,
+		AgP:          e.AgP,
+		TrA:          e.TrA,
+		TrL:          e.TrL,
+		MnA:          e.MnA,
+		MnL:          e.MnL,
+		EcA:          e.EcA,
+		EcAConverged: !e.EcAConvErr,
+		Warnings:     make([]string, len(e.Warnings)),
+	}
+
+	for i, w := range e.Warnings {
+		r.Warnings[i] = w.Error()
+	}
+
+	pr, pv := e.PerifocalState()
+	r.PfRX, r.PfRY, r.PfRZ = pr[0], pr[1], pr[2]
+	r.PfVX, r.PfVY, r.PfVZ = pv[0], pv[1], pv[2]
+
+	er, ev := e.InertialState()
+	r.EciRX, r.EciRY, r.EciRZ = er[0], er[1], er[2]
+	r.EciVX, r.EciVY, r.EciVZ = ev[0], ev[1], ev[2]
+
+	return &r
+}
+
+// Returns the names of the record fields and their values formatted
+// for CSV, in the order of declaration. The names are the same as in JSON.
+func (r *elementsRecord) fields() ([]string, []string) {
+	v := reflect.ValueOf(r).Elem()
+
+	names := make([]string, v.NumField())
+	values := make([]string, v.NumField())
+
+	for i := range names {
+		names[i], _, _ = strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+
+		switch f := v.Field(i).Interface().(type) {
+		case string:
+			values[i] = f
+		case float64:
+			values[i] = strconv.FormatFloat(f, 'g', -1, 64)
+		case bool:
+			values[i] = strconv.FormatBool(f)
+		case []string:
+			values[i] = strings.Join(f, "; ")
+		}
+	}
+
+	return names, values
+}
+
+// Writes the elements in JSON format, as an array of objects.
+// The names of the fields carry the units.
+func WriteElementsJSON(w io.Writer, list []*Elements) error {
+	records := make([]*elementsRecord, len(list))
+	for i, e := range list {
+		records[i] = newElementsRecord(e)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(records)
+}
+
+// Writes the elements in CSV format, one object per row, preceded
+// by the header row. The names of the columns carry the units.
+// Warnings are joined with semicolons.
+func WriteElementsCSV(w io.Writer, list []*Elements) error {
+	cw := csv.NewWriter(w)
+
+	header, _ := new(elementsRecord).fields()
+	cw.Write(header)
+
+	for _, e := range list {
+		_, row := newElementsRecord(e).fields()
+		cw.Write(row)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,69 @@
+package orbit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+// Tests WriteElementsJSON and WriteElementsCSV functions. Both outputs
+// must carry the same fields, the exact values and the raw TLE lines.
+func TestWriteElements(t *testing.T) {
+	set := testISS(t)
+
+	e := CalculateElements(set, M_E, R_E)
+
+	var jsonOut, csvOut bytes.Buffer
+
+	if err := WriteElementsJSON(&jsonOut, []*Elements{e, e}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteElementsCSV(&csvOut, []*Elements{e, e}); err != nil {
+		t.Fatal(err)
+	}
+
+	var objects []map[string]any
+	if err := json.Unmarshal(jsonOut.Bytes(), &objects); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 || len(rows) != 3 {
+		t.Fatalf("Improper count: %d objects, %d rows", len(objects), len(rows))
+	}
+
+	if len(objects[0]) != len(rows[0]) {
+		t.Fatalf("Field count differs: JSON %d, CSV %d", len(objects[0]), len(rows[0]))
+	}
+
+	for i, name := range rows[0] {
+		if _, ok := objects[0][name]; !ok {
+			t.Fatalf("CSV column %s not found in JSON", name)
+		}
+
+		if name == "semi_major_axis_m" {
+			if v, _ := strconv.ParseFloat(rows[1][i], 64); v != e.SMa {
+				t.Fatalf("CSV SMa %s, expected %g", rows[1][i], e.SMa)
+			}
+		}
+	}
+
+	if objects[0]["line1"] != set.Match.Line1 || objects[0]["line2"] != set.Match.Line2 {
+		t.Fatalf("Improper lines: %v, %v", objects[0]["line1"], objects[0]["line2"])
+	}
+
+	if objects[0]["semi_major_axis_m"] != e.SMa || objects[0]["inclination_deg"] != e.Inc {
+		t.Fatalf("Improper values: %v, %v", objects[0]["semi_major_axis_m"], objects[0]["inclination_deg"])
+	}
+
+	if objects[0]["epoch"] != "2022-01-14T04:49:07Z" {
+		t.Fatalf("Improper epoch: %v", objects[0]["epoch"])
+	}
+}
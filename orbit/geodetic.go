@@ -0,0 +1,148 @@
+// Functions within this file convert the TEME state vectors produced by SGP4
+// into Earth-fixed coordinates. Angular values are expressed in degrees
+// and distances in meters, unless explicitly stated otherwise.
+//
+// Polar motion and the difference between UT1 and UTC are neglected, which
+// limits the accuracy of the conversion to tens of meters.
+
+package orbit
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	// Angular velocity of Earth's rotation [rad/s]
+	OMEGA_E float64 = 7.29211514670698e-5
+
+	// WGS-84 equatorial radius of Earth
+	WGS84_A float64 = 6.378137e+6
+
+	// WGS-84 flattening of Earth
+	WGS84_F float64 = 1 / 298.257223563
+)
+
+// Error returned by GroundTrack if the step is not a positive duration.
+var errStep = errors.New("step must be positive")
+
+// GroundPoint describes the sub-satellite point at a given time.
+type GroundPoint struct {
+	Time time.Time
+
+	// Geodetic latitude
+	Lat float64
+
+	// Geodetic longitude, positive eastwards
+	Lon float64
+
+	// Height above the ellipsoid
+	Hgt float64
+}
+
+// Rotates the position vector r from the TEME frame to the Earth-fixed
+// frame at time t.
+func TEMEToECEF(r Vector, t time.Time) Vector {
+	gmst := GMST(t)
+
+	sin, cos := math.Sincos(gmst)
+
+	return Vector{
+		cos*r[0] + sin*r[1],
+		-sin*r[0] + cos*r[1],
+		r[2],
+	}
+}
+
+// Converts the velocity vector v of an object at position r from the TEME
+// frame to the Earth-fixed frame at time t. The returned velocity is
+// relative to the rotating Earth.
+func TEMEVelocityToECEF(r, v Vector, t time.Time) Vector {
+	rf := TEMEToECEF(r, t)
+	vf := TEMEToECEF(v, t)
+
+	return Vector{
+		vf[0] + OMEGA_E*rf[1],
+		vf[1] - OMEGA_E*rf[0],
+		vf[2],
+	}
+}
+
+// Converts the Earth-fixed position vector r into geodetic latitude,
+// longitude and height above the WGS-84 ellipsoid.
+func ECEFToGeodetic(r Vector) (lat, lon, hgt float64) {
+	return bodyToGeodetic(r, WGS84_A, WGS84_F)
+}
+
+// Converts the body-fixed position vector r into geodetic latitude,
+// longitude and height above the ellipsoid given by its equatorial
+// radius a and flattening f.
+func bodyToGeodetic(r Vector, a, f float64) (lat, lon, hgt float64) {
+	const maxIter int = 10
+
+	e2 := f * (2 - f)
+	p := math.Hypot(r[0], r[1])
+
+	lonRad := math.Atan2(r[1], r[0])
+	latRad := math.Atan2(r[2], p*(1-e2))
+
+	var n float64
+
+	for i := 0; i < maxIter; i++ {
+		sin := math.Sin(latRad)
+		n = a / math.Sqrt(1-e2*sin*sin)
+
+		next := math.Atan2(r[2]+e2*n*sin, p)
+		if math.Abs(next-latRad) < 1e-12 {
+			latRad = next
+			break
+		}
+		latRad = next
+	}
+
+	sin, cos := math.Sincos(latRad)
+	n = a / math.Sqrt(1-e2*sin*sin)
+	hgt = p*cos + (r[2]+e2*n*sin)*sin - n
+
+	return Deg(latRad), Deg(lonRad), hgt
+}
+
+// Returns the sub-satellite point at time t.
+func SubSatellitePoint(s *SGP4, t time.Time) (*GroundPoint, error) {
+	r, _, err := s.Propagate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var gp GroundPoint
+
+	gp.Time = t
+	gp.Lat, gp.Lon, gp.Hgt = ECEFToGeodetic(TEMEToECEF(r, t))
+
+	return &gp, nil
+}
+
+// Generates the ground track covering the specified number of orbits,
+// starting at time start. Sub-satellite points are spaced by step.
+// If the propagation fails, the points computed so far are returned
+// along with the error.
+func GroundTrack(s *SGP4, start time.Time, orbits float64, step time.Duration) ([]*GroundPoint, error) {
+	if step <= 0 {
+		return nil, errStep
+	}
+
+	end := start.Add(time.Duration(orbits * float64(s.Period())))
+
+	track := make([]*GroundPoint, 0, int(end.Sub(start)/step)+1)
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		gp, err := SubSatellitePoint(s, t)
+		if err != nil {
+			return track, err
+		}
+		track = append(track, gp)
+	}
+
+	return track, nil
+}
@@ -0,0 +1,60 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests GMST function against the sidereal time at J2000.0.
+func TestGMST(t *testing.T) {
+	j2000 := time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	if gmst := Deg(GMST(j2000)); math.Abs(gmst-280.46061837) > 1e-6 {
+		t.Fatalf("GMST at J2000.0: %.8f", gmst)
+	}
+}
+
+// Tests ECEFToGeodetic function. Points on the equator and at the pole
+// must lie on the ellipsoid, and a point above the 45th parallel must
+// keep its height.
+func TestECEFToGeodetic(t *testing.T) {
+	b := WGS84_A * (1 - WGS84_F)
+
+	cases := []struct {
+		R             Vector
+		Lat, Lon, Hgt float64
+	}{
+		{Vector{WGS84_A, 0, 0}, 0, 0, 0},
+		{Vector{0, -WGS84_A - 1000, 0}, 0, -90, 1000},
+		{Vector{0, 0, b + 500}, 90, 0, 500},
+	}
+
+	for _, c := range cases {
+		lat, lon, hgt := ECEFToGeodetic(c.R)
+		if math.Abs(lat-c.Lat) > 1e-9 || math.Abs(lon-c.Lon) > 1e-9 || math.Abs(hgt-c.Hgt) > 1e-6 {
+			t.Fatalf("%v: %f %f %f", c.R, lat, lon, hgt)
+		}
+	}
+}
+
+// Tests GroundTrack function. One orbit of ISS must produce points spaced
+// by the step, with latitudes bounded by the inclination.
+func TestGroundTrack(t *testing.T) {
+	s := testISSProp(t)
+
+	track, err := GroundTrack(s, s.Epoch(), 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(track) != int(s.Period()/time.Minute)+1 {
+		t.Fatalf("Improper number of points: %d", len(track))
+	}
+
+	for i, gp := range track {
+		if math.Abs(gp.Lat) > 52 || gp.Hgt < 380e3 || gp.Hgt > 440e3 {
+			t.Fatalf("Point %d out of bounds: %+v", i, gp)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package orbit
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Observer describes the location of a ground station on the WGS-84 ellipsoid.
+type Observer struct {
+	// Geodetic latitude
+	Lat float64
+
+	// Geodetic longitude, positive eastwards
+	Lon float64
+
+	// Height above the ellipsoid
+	Hgt float64
+}
+
+// Look holds the topocentric look angles from the observer to an object.
+type Look struct {
+	// Azimuth, measured clockwise from the north
+	Az float64
+
+	// Elevation above the horizon
+	El float64
+
+	// Distance between the observer and the object
+	Rng float64
+
+	// Rate of change of the distance, positive if the object recedes
+	RRt float64
+}
+
+// Creates a new observer located at the specified geodetic latitude,
+// longitude and height above the ellipsoid. Returns an error if
+// the coordinates are out of range.
+func NewObserver(lat, lon, hgt float64) (*Observer, error) {
+	if lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("latitude out of range <-90;90>: %g", lat)
+	}
+
+	if lon < -180 || lon > 360 {
+		return nil, fmt.Errorf("longitude out of range <-180;360>: %g", lon)
+	}
+
+	if lon > 180 {
+		lon -= 360
+	}
+
+	return &Observer{Lat: lat, Lon: lon, Hgt: hgt}, nil
+}
+
+// Returns the Earth-fixed position vector of the observer.
+func (o *Observer) Position() Vector {
+	return GeodeticToECEF(o.Lat, o.Lon, o.Hgt)
+}
+
+// Returns the look angles from the observer to the object at time t.
+func (o *Observer) Look(s *SGP4, t time.Time) (*Look, error) {
+	r, v, err := s.Propagate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	d := TEMEToECEF(r, t).Sub(o.Position())
+	dv := TEMEVelocityToECEF(r, v, t)
+
+	sinLat, cosLat := math.Sincos(Rad(o.Lat))
+	sinLon, cosLon := math.Sincos(Rad(o.Lon))
+
+	// Topocentric east, north and up components
+	east := -sinLon*d[0] + cosLon*d[1]
+	north := -sinLat*cosLon*d[0] - sinLat*sinLon*d[1] + cosLat*d[2]
+	up := cosLat*cosLon*d[0] + cosLat*sinLon*d[1] + sinLat*d[2]
+
+	var l Look
+
+	l.Rng = d.Norm()
+	l.RRt = d.Dot(dv) / l.Rng
+	l.El = Deg(math.Asin(up / l.Rng))
+	l.Az = Deg(math.Atan2(east, north))
+	if l.Az < 0 {
+		l.Az += 360
+	}
+
+	return &l, nil
+}
+
+// Converts geodetic latitude, longitude and height above the WGS-84
+// ellipsoid into the Earth-fixed position vector.
+func GeodeticToECEF(lat, lon, hgt float64) Vector {
+	e2 := WGS84_F * (2 - WGS84_F)
+
+	sinLat, cosLat := math.Sincos(Rad(lat))
+	sinLon, cosLon := math.Sincos(Rad(lon))
+
+	n := WGS84_A / math.Sqrt(1-e2*sinLat*sinLat)
+
+	return Vector{
+		(n + hgt) * cosLat * cosLon,
+		(n + hgt) * cosLat * sinLon,
+		(n*(1-e2) + hgt) * sinLat,
+	}
+}
@@ -0,0 +1,35 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests Observer.Look method. The range rate must agree with the numerical
+// derivative of the range, and an object directly above the observer
+// must be seen at the elevation of 90 degrees.
+func TestLook(t *testing.T) {
+	s := testISSProp(t)
+
+	obs, _ := NewObserver(52.23, 21.01, 100)
+
+	for i := 0; i < 10; i++ {
+		tm := s.Epoch().Add(time.Duration(i) * 17 * time.Minute)
+
+		l, _ := obs.Look(s, tm)
+		before, _ := obs.Look(s, tm.Add(-500*time.Millisecond))
+		after, _ := obs.Look(s, tm.Add(500*time.Millisecond))
+
+		if rrt := after.Rng - before.Rng; math.Abs(rrt-l.RRt) > 0.5 {
+			t.Fatalf("%v: range rate %f, numerical %f", tm, l.RRt, rrt)
+		}
+	}
+
+	gp, _ := SubSatellitePoint(s, s.Epoch())
+	above, _ := NewObserver(gp.Lat, gp.Lon, 0)
+
+	if l, _ := above.Look(s, s.Epoch()); math.Abs(l.El-90) > 1e-6 || math.Abs(l.Rng-gp.Hgt) > 1e-3 {
+		t.Fatalf("Object overhead: El %f, Rng %f, Hgt %f", l.El, l.Rng, gp.Hgt)
+	}
+}
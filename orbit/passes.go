@@ -0,0 +1,187 @@
+package orbit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+const (
+	// Step of the coarse search for horizon crossings. The passes shorter
+	// than the step are found around the local maxima of elevation.
+	PASS_SEARCH_STEP = 30 * time.Second
+
+	// Precision of the horizon crossing and culmination times
+	PASS_PRECISION = 100 * time.Millisecond
+)
+
+// Pass describes a single pass of an object above the observer's horizon.
+// Passes in progress at the beginning or at the end of the search window
+// are clipped to the window.
+type Pass struct {
+	// Acquisition of signal - object rises above the horizon
+	AOS time.Time
+
+	// Time of closest approach - object reaches the maximum elevation
+	TCA time.Time
+
+	// Loss of signal - object sets below the horizon
+	LOS time.Time
+
+	// Maximum elevation reached during the pass
+	MaxEl float64
+
+	// Azimuths at AOS and LOS
+	AOSAz, LOSAz float64
+}
+
+// Predicts the passes of the object described by the TLE set above
+// the observer's horizon between start and end.
+func PredictPasses(set *tle.TLE, obs *Observer, start, end time.Time) ([]*Pass, error) {
+	s, err := NewSGP4(set)
+	if err != nil {
+		return nil, err
+	}
+	return FindPasses(s, obs, start, end)
+}
+
+// Finds the passes of the propagated object above the observer's horizon
+// between start and end. If the propagation fails, the passes completed
+// so far are returned along with the error. The pass in progress is dropped.
+func FindPasses(s *SGP4, obs *Observer, start, end time.Time) ([]*Pass, error) {
+	f := passFinder{s: s, obs: obs}
+
+	var (
+		passes []*Pass
+		cur    *Pass
+	)
+
+	// Two preceding samples, so that the local maxima can be told
+	lastT, lastEl := start, math.Inf(-1)
+	prevT, prevEl := start, f.elevation(start)
+
+	if prevEl > 0 {
+		cur = &Pass{AOS: start}
+	}
+
+	for t := start; t.Before(end) && f.err == nil; {
+		t = t.Add(PASS_SEARCH_STEP)
+		if t.After(end) {
+			t = end
+		}
+
+		el := f.elevation(t)
+
+		if prevEl <= 0 && el > 0 {
+			cur = &Pass{AOS: f.crossing(prevT, t)}
+		} else if prevEl > 0 && el <= 0 && cur != nil {
+			cur.LOS = f.crossing(prevT, t)
+			if f.complete(cur); f.err != nil {
+				break
+			}
+			passes = append(passes, cur)
+			cur = nil
+		} else if el <= 0 && prevEl <= 0 && prevEl > lastEl && prevEl >= el {
+			// A pass shorter than the step may culminate between the samples
+			// below the horizon
+			if peak := f.peak(lastT, t); f.elevation(peak) > 0 {
+				p := &Pass{AOS: f.crossing(lastT, peak), LOS: f.crossing(peak, t)}
+				if f.complete(p); f.err != nil {
+					break
+				}
+				passes = append(passes, p)
+			}
+		}
+
+		lastT, lastEl = prevT, prevEl
+		prevT, prevEl = t, el
+	}
+
+	if f.err != nil {
+		return passes, f.err
+	}
+
+	if cur != nil {
+		cur.LOS = end
+		passes = append(passes, f.complete(cur))
+	}
+
+	return passes, f.err
+}
+
+// Helper struct for FindPasses. Stores the first error returned
+// by the propagator, so that the search can be aborted.
+type passFinder struct {
+	s   *SGP4
+	obs *Observer
+	err error
+}
+
+// Returns the look angles at time t.
+func (f *passFinder) look(t time.Time) *Look {
+	if f.err != nil {
+		return &Look{El: math.Inf(-1)}
+	}
+
+	l, err := f.obs.Look(f.s, t)
+	if err != nil {
+		f.err = err
+		return &Look{El: math.Inf(-1)}
+	}
+
+	return l
+}
+
+// Returns the elevation at time t.
+func (f *passFinder) elevation(t time.Time) float64 {
+	return f.look(t).El
+}
+
+// Finds the time of the horizon crossing between a and b by bisection.
+// The object must be on the opposite sides of the horizon at a and b.
+func (f *passFinder) crossing(a, b time.Time) time.Time {
+	aboveA := f.elevation(a) > 0
+
+	for b.Sub(a) > PASS_PRECISION && f.err == nil {
+		mid := a.Add(b.Sub(a) / 2)
+		if (f.elevation(mid) > 0) == aboveA {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+// Finds the time of the maximum elevation between a and b with
+// golden-section search. The elevation must have a single maximum there.
+func (f *passFinder) peak(a, b time.Time) time.Time {
+	// Inverse of the golden ratio
+	invPhi := (math.Sqrt(5) - 1) / 2
+
+	for b.Sub(a) > PASS_PRECISION && f.err == nil {
+		d := time.Duration(float64(b.Sub(a)) * invPhi)
+		c1, c2 := b.Add(-d), a.Add(d)
+
+		if f.elevation(c1) > f.elevation(c2) {
+			b = c2
+		} else {
+			a = c1
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+// Finds the time of closest approach and computes the remaining values
+// of the pass.
+func (f *passFinder) complete(p *Pass) *Pass {
+	p.TCA = f.peak(p.AOS, p.LOS)
+	p.MaxEl = f.elevation(p.TCA)
+	p.AOSAz = f.look(p.AOS).Az
+	p.LOSAz = f.look(p.LOS).Az
+
+	return p
+}
@@ -0,0 +1,117 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests PredictPasses function. ISS must pass over the observer several
+// times within two days. The events of every pass must be in order,
+// the object must be at the horizon at AOS and LOS, and the maximum
+// elevation must exceed the elevation at any other sampled moment.
+func TestPredictPasses(t *testing.T) {
+	set := testISS(t)
+
+	obs, err := NewObserver(52.23, 21.01, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := testISSProp(t)
+
+	passes, err := PredictPasses(set, obs, s.Epoch(), s.Epoch().Add(48*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(passes) < 4 {
+		t.Fatalf("Too few passes found: %d", len(passes))
+	}
+
+	for i, p := range passes {
+		if !(p.AOS.Before(p.TCA) && p.TCA.Before(p.LOS)) {
+			t.Fatalf("Pass %d: events out of order: %+v", i, p)
+		}
+
+		for _, tm := range []time.Time{p.AOS, p.LOS} {
+			l, _ := obs.Look(s, tm)
+			if math.Abs(l.El) > 0.05 {
+				t.Fatalf("Pass %d: elevation at the horizon crossing: %f", i, l.El)
+			}
+		}
+
+		for tm := p.AOS; tm.Before(p.LOS); tm = tm.Add(10 * time.Second) {
+			l, _ := obs.Look(s, tm)
+			if l.El > p.MaxEl+1e-6 {
+				t.Fatalf("Pass %d: elevation %f at %v exceeds maximum %f", i, l.El, tm, p.MaxEl)
+			}
+		}
+	}
+}
+
+// Tests FindPasses with a pass shorter than the search step. ISS barely
+// rises above the horizon of the observer close to the northern limit
+// of its visibility, and the pass must be found nonetheless.
+func TestFindPassesShort(t *testing.T) {
+	obs, err := NewObserver(72.09, 21.01, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := testISSProp(t)
+
+	passes, err := FindPasses(s, obs, s.Epoch(), s.Epoch().Add(48*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(passes) != 1 {
+		t.Fatalf("%d passes found, expected 1", len(passes))
+	}
+
+	p := passes[0]
+	if d := p.LOS.Sub(p.AOS); d >= PASS_SEARCH_STEP || !(p.AOS.Before(p.TCA) && p.TCA.Before(p.LOS)) || p.MaxEl <= 0 {
+		t.Fatalf("Improper pass lasting %v: %+v", d, p)
+	}
+}
+
+// Tests FindPasses with an object that decays above the observer.
+// The search must return the propagation error and must not report
+// the pass interrupted by it.
+func TestFindPassesDecay(t *testing.T) {
+	set := testISS(t)
+	set.L1.BSTAR = 0.05
+
+	s, err := NewSGP4(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The last moment the object can be propagated at
+	last := s.Epoch()
+	for ; ; last = last.Add(time.Second) {
+		if _, _, err := s.Propagate(last.Add(time.Second)); err != nil {
+			break
+		}
+	}
+
+	gp, err := SubSatellitePoint(s, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obs, err := NewObserver(gp.Lat, gp.Lon, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passes, err := FindPasses(s, obs, last.Add(-time.Second), last.Add(time.Hour))
+	if err == nil {
+		t.Fatal("Error expected")
+	}
+
+	if len(passes) != 0 {
+		t.Fatalf("Incomplete pass returned: %+v", passes[0])
+	}
+}
@@ -0,0 +1,1065 @@
+// The SGP4/SDP4 propagator contained in this file was adapted from:
+//
+// Vallado D., A., Crawford P., Hujsak R., Kelso T., S. 2006. Revisiting
+// Spacetrack Report #3. AIAA 2006-6753. [on-line] Available at
+// https://celestrak.org/publications/AIAA/2006-6753 [accessed on 16.10.2026]
+// American Institute of Aeronautics and Astronautics, Keystone, CO.
+//
+// The variable names follow the reference implementation to make the code
+// easier to compare against it. Internally, the model works in Earth radii,
+// minutes and radians. Values crossing the boundary of this file are
+// converted to SI units, in line with the rest of the program.
+//
+// The propagation is performed in the True Equator, Mean Equinox (TEME)
+// reference frame, using the WGS-72 gravity model the element sets
+// are generated with.
+
+package orbit
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+const (
+	// WGS-72 gravitational parameter of Earth [km^3/s^2]
+	sgp4Mu float64 = 398600.8
+
+	// WGS-72 equatorial radius of Earth [km]
+	sgp4Radius float64 = 6378.135
+
+	// WGS-72 zonal harmonic coefficients
+	sgp4J2 float64 = 0.001082616
+	sgp4J3 float64 = -0.00000253881
+	sgp4J4 float64 = -0.00000165597
+
+	// Orbital period above which the deep-space (SDP4) model is used [min]
+	deepSpacePeriod float64 = 225
+
+	// Julian Date of 1949 December 31 00:00 UT, the epoch of the model's day count
+	jd1950 float64 = 2433281.5
+)
+
+var (
+	// Square root of Earth's gravitational parameter in Earth radii^1.5/min
+	sgp4Xke = 60 / math.Sqrt(sgp4Radius*sgp4Radius*sgp4Radius/sgp4Mu)
+
+	// Conversion factor from Earth radii per minute to km/s
+	sgp4VelUnit = sgp4Radius * sgp4Xke / 60
+
+	sgp4J3oJ2 = sgp4J3 / sgp4J2
+)
+
+var (
+	// The errors returned by the propagator if the elements evolve into
+	// a state that the model cannot describe.
+	errMeanMotion      = errors.New("mean motion is not positive")
+	errMeanEccentric   = errors.New("mean eccentricity is out of range")
+	errPerturbEccentic = errors.New("perturbed eccentricity is out of range")
+	errSemiLatusRectum = errors.New("semi-latus rectum is negative")
+	errDecayed         = errors.New("satellite has decayed")
+)
+
+// SGP4 holds the propagator state initialized from a single TLE set.
+// The near-Earth SGP4 model is used for orbital periods shorter than
+// 225 minutes, the deep-space SDP4 model otherwise. The deep-space
+// resonance integrator is stateful, so a single SGP4 struct must not be
+// shared between goroutines.
+type SGP4 struct {
+	// Epoch of the element set
+	epoch time.Time
+
+	// Use deep-space model
+	deep bool
+
+	// Simplified drag model for low perigee orbits
+	isimp bool
+
+	// Elements at epoch
+	bstar, ecco, argpo, inclo, mo, no, nodeo float64
+
+	// Near-Earth coefficients
+	aycof, con41, cc1, cc4, cc5, d2, d3, d4, delmo, eta, argpdot, omgcof,
+	sinmao, t2cof, t3cof, t4cof, t5cof, x1mth2, x7thm1, mdot, nodedot,
+	xlcof, xmcof, nodecf float64
+
+	// Deep-space coefficients
+	irez int
+
+	d2201, d2211, d3210, d3222, d4410, d4422, d5220, d5232, d5421, d5433,
+	dedt, del1, del2, del3, didt, dmdt, dnodt, domdt, e3, ee2, peo, pgho,
+	pho, pinco, plo, se2, se3, sgh2, sgh3, sgh4, sh2, sh3, si2, si3, sl2,
+	sl3, sl4, gsto, xfact, xgh2, xgh3, xgh4, xh2, xh3, xi2, xi3, xl2, xl3,
+	xl4, xlamo, zmol, zmos, atime, xli, xni float64
+}
+
+// Propagates the TLE set to time t. Returns position [m] and velocity [m/s]
+// in the TEME reference frame.
+func Propagate(set *tle.TLE, t time.Time) (Vector, Vector, error) {
+	s, err := NewSGP4(set)
+	if err != nil {
+		return Vector{}, Vector{}, err
+	}
+	return s.Propagate(t)
+}
+
+// Initializes the propagator with values contained in the TLE struct.
+func NewSGP4(set *tle.TLE) (*SGP4, error) {
+	// Minutes per radian of a revolution per day
+	const xpdotp float64 = 1440 / (2 * math.Pi)
+
+	var s SGP4
+
+	s.epoch = tle.EpochToTime(set.L1.Epoch.Year, set.L1.Epoch.Day)
+
+	s.bstar = set.L1.BSTAR
+	s.ecco = set.L2.Ecc
+	s.argpo = Rad(set.L2.AgP)
+	s.inclo = Rad(set.L2.Inc)
+	s.mo = Rad(set.L2.MnA)
+	s.no = set.L2.MnM / xpdotp
+	s.nodeo = Rad(set.L2.LAN)
+
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Returns the epoch of the element set the propagator was initialized with.
+func (s *SGP4) Epoch() time.Time {
+	return s.epoch
+}
+
+// Returns true if the deep-space model is used.
+func (s *SGP4) DeepSpace() bool {
+	return s.deep
+}
+
+// Returns the orbital period derived from the recovered mean motion.
+func (s *SGP4) Period() time.Duration {
+	return time.Duration(2 * math.Pi / s.no * float64(time.Minute))
+}
+
+// Propagates the elements to time t. Returns position [m] and velocity [m/s]
+// in the TEME reference frame.
+func (s *SGP4) Propagate(t time.Time) (Vector, Vector, error) {
+	r, v, err := s.propagate(t.Sub(s.epoch).Minutes())
+	return r.Scale(1e3), v.Scale(1e3), err
+}
+
+// Recovers the original (Brouwer) mean motion from the Kozai mean motion
+// no [rad/min] given the eccentricity, the cosine of the inclination,
+// the square root of the gravitational parameter in body radii^1.5/min
+// and the second zonal harmonic (initl).
+func unKozai(no, ecc, cosio, xke, j2 float64) float64 {
+	const x2o3 float64 = 2.0 / 3.0
+
+	omeosq := 1 - ecc*ecc
+	rteosq := math.Sqrt(omeosq)
+	cosio2 := cosio * cosio
+
+	ak := math.Pow(xke/no, x2o3)
+	d1 := 0.75 * j2 * (3*cosio2 - 1) / (rteosq * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1 - del*del - del*(1.0/3.0+134*del*del/81))
+	del = d1 / (adel * adel)
+
+	return no / (1 + del)
+}
+
+// Initializes the model coefficients (sgp4init and initl).
+func (s *SGP4) init() error {
+	const (
+		x2o3  float64 = 2.0 / 3.0
+		temp4 float64 = 1.5e-12
+	)
+
+	ss := 78/sgp4Radius + 1
+	qzms2t := math.Pow((120-78)/sgp4Radius, 4)
+
+	// Days since 1950 and the sidereal time at epoch
+	epoch := TimeToJDN(s.epoch) - jd1950
+	s.gsto = GMST(s.epoch)
+
+	// -------- initl -------- //
+
+	eccsq := s.ecco * s.ecco
+	omeosq := 1 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(s.inclo)
+	cosio2 := cosio * cosio
+
+	// Un-Kozai the mean motion
+	s.no = unKozai(s.no, s.ecco, cosio, sgp4Xke, sgp4J2)
+
+	ao := math.Pow(sgp4Xke/s.no, x2o3)
+	sinio := math.Sin(s.inclo)
+	po := ao * omeosq
+	con42 := 1 - 5*cosio2
+	s.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := ao * (1 - s.ecco)
+
+	// ------ sgp4init ------- //
+
+	if omeosq < 0 && s.no < 0 {
+		return errMeanEccentric
+	}
+
+	s.isimp = rp < 220/sgp4Radius+1
+
+	sfour := ss
+	qzms24 := qzms2t
+	perige := (rp - 1) * sgp4Radius
+
+	// For perigees below 156 km, s and qoms2t are altered
+	if perige < 156 {
+		sfour = perige - 78
+		if perige < 98 {
+			sfour = 20
+		}
+		qzms24 = math.Pow((120-sfour)/sgp4Radius, 4)
+		sfour = sfour/sgp4Radius + 1
+	}
+	pinvsq := 1 / posq
+
+	tsi := 1 / (ao - sfour)
+	s.eta = ao * s.ecco * tsi
+	etasq := s.eta * s.eta
+	eeta := s.ecco * s.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+	cc2 := coef1 * s.no * (ao*(1+1.5*etasq+eeta*(4+etasq)) +
+		0.375*sgp4J2*tsi/psisq*s.con41*(8+3*etasq*(8+etasq)))
+	s.cc1 = s.bstar * cc2
+
+	var cc3 float64
+	if s.ecco > 1e-4 {
+		cc3 = -2 * coef * tsi * sgp4J3oJ2 * s.no * sinio / s.ecco
+	}
+
+	s.x1mth2 = 1 - cosio2
+	s.cc4 = 2 * s.no * coef1 * ao * omeosq *
+		(s.eta*(2+0.5*etasq) + s.ecco*(0.5+2*etasq) -
+			sgp4J2*tsi/(ao*psisq)*(-3*s.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+
+				0.75*s.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*s.argpo)))
+	s.cc5 = 2 * coef1 * ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * sgp4J2 * pinvsq * s.no
+	temp2 := 0.5 * temp1 * sgp4J2 * pinvsq
+	temp3 := -0.46875 * sgp4J4 * pinvsq * pinvsq * s.no
+
+	s.mdot = s.no + 0.5*temp1*rteosq*s.con41 + 0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+	s.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) +
+		temp3*(3-36*cosio2+49*cosio4)
+
+	xhdot1 := -temp1 * cosio
+	s.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*cosio
+	xpidot := s.argpdot + s.nodedot
+
+	s.omgcof = s.bstar * cc3 * math.Cos(s.argpo)
+	if s.ecco > 1e-4 {
+		s.xmcof = -x2o3 * coef * s.bstar / eeta
+	}
+	s.nodecf = 3.5 * omeosq * xhdot1 * s.cc1
+	s.t2cof = 1.5 * s.cc1
+
+	// Avoids division by zero for inclination of 180 degrees
+	if math.Abs(cosio+1) > temp4 {
+		s.xlcof = -0.25 * sgp4J3oJ2 * sinio * (3 + 5*cosio) / (1 + cosio)
+	} else {
+		s.xlcof = -0.25 * sgp4J3oJ2 * sinio * (3 + 5*cosio) / temp4
+	}
+	s.aycof = -0.5 * sgp4J3oJ2 * sinio
+	s.delmo = math.Pow(1+s.eta*math.Cos(s.mo), 3)
+	s.sinmao = math.Sin(s.mo)
+	s.x7thm1 = 7*cosio2 - 1
+
+	// Deep-space initialization
+	if 2*math.Pi/s.no >= deepSpacePeriod {
+		s.deep = true
+		s.isimp = true
+
+		inclm := s.inclo
+
+		ds := s.dscom(epoch, s.ecco, s.argpo, 0, s.inclo, s.nodeo, s.no)
+
+		s.dsinit(&ds, 0, 0, xpidot, eccsq, inclm)
+	}
+
+	// Set variables if not deep space
+	if !s.isimp {
+		cc1sq := s.cc1 * s.cc1
+		s.d2 = 4 * ao * tsi * cc1sq
+		temp := s.d2 * tsi * s.cc1 / 3
+		s.d3 = (17*ao + sfour) * temp
+		s.d4 = 0.5 * temp * ao * tsi * (221*ao + 31*sfour) * s.cc1
+		s.t3cof = s.d2 + 2*cc1sq
+		s.t4cof = 0.25 * (3*s.d3 + s.cc1*(12*s.d2+10*cc1sq))
+		s.t5cof = 0.2 * (3*s.d4 + 12*s.cc1*s.d3 + 6*s.d2*s.d2 + 15*cc1sq*(2*s.d2+cc1sq))
+	}
+
+	_, _, err := s.propagate(0)
+	return err
+}
+
+// Propagates the elements by tsince minutes from epoch. Returns position [km]
+// and velocity [km/s] in the TEME reference frame.
+func (s *SGP4) propagate(tsince float64) (Vector, Vector, error) {
+	const (
+		x2o3  float64 = 2.0 / 3.0
+		temp4 float64 = 1.5e-12
+		twoPi float64 = 2 * math.Pi
+	)
+
+	var r, v Vector
+
+	t := tsince
+
+	// Update for secular gravity and atmospheric drag
+	xmdf := s.mo + s.mdot*t
+	argpdf := s.argpo + s.argpdot*t
+	nodedf := s.nodeo + s.nodedot*t
+	argpm := argpdf
+	mm := xmdf
+	t2 := t * t
+	nodem := nodedf + s.nodecf*t2
+	tempa := 1 - s.cc1*t
+	tempe := s.bstar * s.cc4 * t
+	templ := s.t2cof * t2
+
+	if !s.isimp {
+		delomg := s.omgcof * t
+		delm := s.xmcof * (math.Pow(1+s.eta*math.Cos(xmdf), 3) - s.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * t
+		t4 := t3 * t
+		tempa = tempa - s.d2*t2 - s.d3*t3 - s.d4*t4
+		tempe = tempe + s.bstar*s.cc5*(math.Sin(mm)-s.sinmao)
+		templ = templ + s.t3cof*t3 + t4*(s.t4cof+t*s.t5cof)
+	}
+
+	nm := s.no
+	em := s.ecco
+	inclm := s.inclo
+
+	if s.deep {
+		s.dspace(t, &em, &argpm, &inclm, &mm, &nodem, &nm)
+	}
+
+	if nm <= 0 {
+		return r, v, errMeanMotion
+	}
+
+	am := math.Pow(sgp4Xke/nm, x2o3) * tempa * tempa
+	nm = sgp4Xke / math.Pow(am, 1.5)
+	em = em - tempe
+
+	if em >= 1 || em < -0.001 {
+		return r, v, errMeanEccentric
+	}
+
+	// Avoids division by zero
+	if em < 1e-6 {
+		em = 1e-6
+	}
+
+	mm = mm + s.no*templ
+	xlm := mm + argpm + nodem
+
+	nodem = math.Mod(nodem, twoPi)
+	argpm = math.Mod(argpm, twoPi)
+	xlm = math.Mod(xlm, twoPi)
+	mm = math.Mod(xlm-argpm-nodem, twoPi)
+
+	// Lunar-solar periodics
+	ep := em
+	xincp := inclm
+	argpp := argpm
+	nodep := nodem
+	mp := mm
+	sinip := math.Sin(inclm)
+	cosip := math.Cos(inclm)
+
+	if s.deep {
+		s.dpper(t, &ep, &xincp, &nodep, &argpp, &mp)
+
+		if xincp < 0 {
+			xincp = -xincp
+			nodep = nodep + math.Pi
+			argpp = argpp - math.Pi
+		}
+
+		if ep < 0 || ep > 1 {
+			return r, v, errPerturbEccentic
+		}
+
+		// Long period periodics
+		sinip = math.Sin(xincp)
+		cosip = math.Cos(xincp)
+		s.aycof = -0.5 * sgp4J3oJ2 * sinip
+
+		if math.Abs(cosip+1) > temp4 {
+			s.xlcof = -0.25 * sgp4J3oJ2 * sinip * (3 + 5*cosip) / (1 + cosip)
+		} else {
+			s.xlcof = -0.25 * sgp4J3oJ2 * sinip * (3 + 5*cosip) / temp4
+		}
+	}
+
+	axnl := ep * math.Cos(argpp)
+	temp := 1 / (am * (1 - ep*ep))
+	aynl := ep*math.Sin(argpp) + temp*s.aycof
+	xl := mp + argpp + nodep + temp*s.xlcof*axnl
+
+	// Solve Kepler's equation
+	u := math.Mod(xl-nodep, twoPi)
+	eo1 := u
+	tem5 := 9999.9
+
+	var sineo1, coseo1 float64
+
+	for ktr := 1; math.Abs(tem5) >= 1e-12 && ktr <= 10; ktr++ {
+		sineo1 = math.Sin(eo1)
+		coseo1 = math.Cos(eo1)
+		tem5 = 1 - coseo1*axnl - sineo1*aynl
+		tem5 = (u - aynl*coseo1 + axnl*sineo1 - eo1) / tem5
+		if math.Abs(tem5) >= 0.95 {
+			tem5 = math.Copysign(0.95, tem5)
+		}
+		eo1 = eo1 + tem5
+	}
+
+	// Short period preliminary quantities
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+
+	if pl < 0 {
+		return r, v, errSemiLatusRectum
+	}
+
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * sgp4J2 * temp
+	temp2 := temp1 * temp
+
+	// Update for short period periodics
+	if s.deep {
+		cosisq := cosip * cosip
+		s.con41 = 3*cosisq - 1
+		s.x1mth2 = 1 - cosisq
+		s.x7thm1 = 7*cosisq - 1
+	}
+
+	mrt := rl*(1-1.5*temp2*betal*s.con41) + 0.5*temp1*s.x1mth2*cos2u
+	su = su - 0.25*temp2*s.x7thm1*sin2u
+	xnode := nodep + 1.5*temp2*cosip*sin2u
+	xinc := xincp + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*s.x1mth2*sin2u/sgp4Xke
+	rvdot := rvdotl + nm*temp1*(s.x1mth2*cos2u+1.5*s.con41)/sgp4Xke
+
+	// Orientation vectors
+	sinsu := math.Sin(su)
+	cossu := math.Cos(su)
+	snod := math.Sin(xnode)
+	cnod := math.Cos(xnode)
+	sini := math.Sin(xinc)
+	cosi := math.Cos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+
+	ux := xmx*sinsu + cnod*cossu
+	uy := xmy*sinsu + snod*cossu
+	uz := sini * sinsu
+	vx := xmx*cossu - cnod*sinsu
+	vy := xmy*cossu - snod*sinsu
+	vz := sini * cossu
+
+	r = Vector{mrt * ux, mrt * uy, mrt * uz}.Scale(sgp4Radius)
+	v = Vector{mvt*ux + rvdot*vx, mvt*uy + rvdot*vy, mvt*uz + rvdot*vz}.Scale(sgp4VelUnit)
+
+	if mrt < 1 {
+		return r, v, errDecayed
+	}
+
+	return r, v, nil
+}
+
+// Intermediate values passed from dscom to dsinit.
+type deepSpaceCommon struct {
+	sinim, cosim, em, emsq, nm,
+	s1, s2, s3, s4, s5, ss1, ss2, ss3, ss4, ss5,
+	sz1, sz3, sz11, sz13, sz21, sz23, sz31, sz33,
+	z1, z3, z11, z13, z21, z23, z31, z33 float64
+}
+
+// Computes the lunar and solar terms of the deep-space model. Accepts
+// epoch [days since 1950], eccentricity, argument of perigee [rad],
+// time [min], inclination [rad], right ascension of the ascending
+// node [rad] and mean motion [rad/min].
+func (s *SGP4) dscom(epoch, ep, argpp, tc, inclp, nodep, np float64) deepSpaceCommon {
+	const (
+		zes    float64 = 0.01675
+		zel    float64 = 0.05490
+		c1ss   float64 = 2.9864797e-6
+		c1l    float64 = 4.7968065e-7
+		zsinis float64 = 0.39785416
+		zcosis float64 = 0.91744867
+		zcosgs float64 = 0.1945905
+		zsings float64 = -0.98088458
+		twoPi  float64 = 2 * math.Pi
+	)
+
+	var d deepSpaceCommon
+
+	d.nm = np
+	d.em = ep
+	snodm := math.Sin(nodep)
+	cnodm := math.Cos(nodep)
+	sinomm := math.Sin(argpp)
+	cosomm := math.Cos(argpp)
+	d.sinim = math.Sin(inclp)
+	d.cosim = math.Cos(inclp)
+	d.emsq = d.em * d.em
+	betasq := 1 - d.emsq
+	rtemsq := math.Sqrt(betasq)
+
+	// Initialize lunar solar terms
+	s.peo = 0
+	s.pinco = 0
+	s.plo = 0
+	s.pgho = 0
+	s.pho = 0
+
+	day := epoch + 18261.5 + tc/1440
+	xnodce := math.Mod(4.5236020-9.2422029e-4*day, twoPi)
+	stem := math.Sin(xnodce)
+	ctem := math.Cos(xnodce)
+	zcosil := 0.91375164 - 0.03568096*ctem
+	zsinil := math.Sqrt(1 - zcosil*zcosil)
+	zsinhl := 0.089683511 * stem / zsinil
+	zcoshl := math.Sqrt(1 - zsinhl*zsinhl)
+	gam := 5.8351514 + 0.0019443680*day
+	zx := 0.39785416 * stem / zsinil
+	zy := zcoshl*ctem + 0.91744867*zsinhl*stem
+	zx = math.Atan2(zx, zy)
+	zx = gam + zx - xnodce
+	zcosgl := math.Cos(zx)
+	zsingl := math.Sin(zx)
+
+	// Solar terms are computed first, lunar terms in the second pass
+	zcosg := zcosgs
+	zsing := zsings
+	zcosi := zcosis
+	zsini := zsinis
+	zcosh := cnodm
+	zsinh := snodm
+	cc := c1ss
+	xnoi := 1 / d.nm
+
+	var (
+		s6, s7, ss6, ss7, sz2, sz12, sz22, sz32,
+		z2, z12, z22, z32 float64
+	)
+
+	for lsflg := 1; lsflg <= 2; lsflg++ {
+		a1 := zcosg*zcosh + zsing*zcosi*zsinh
+		a3 := -zsing*zcosh + zcosg*zcosi*zsinh
+		a7 := -zcosg*zsinh + zsing*zcosi*zcosh
+		a8 := zsing * zsini
+		a9 := zsing*zsinh + zcosg*zcosi*zcosh
+		a10 := zcosg * zsini
+		a2 := d.cosim*a7 + d.sinim*a8
+		a4 := d.cosim*a9 + d.sinim*a10
+		a5 := -d.sinim*a7 + d.cosim*a8
+		a6 := -d.sinim*a9 + d.cosim*a10
+
+		x1 := a1*cosomm + a2*sinomm
+		x2 := a3*cosomm + a4*sinomm
+		x3 := -a1*sinomm + a2*cosomm
+		x4 := -a3*sinomm + a4*cosomm
+		x5 := a5 * sinomm
+		x6 := a6 * sinomm
+		x7 := a5 * cosomm
+		x8 := a6 * cosomm
+
+		d.z31 = 12*x1*x1 - 3*x3*x3
+		z32 = 24*x1*x2 - 6*x3*x4
+		d.z33 = 12*x2*x2 - 3*x4*x4
+		d.z1 = 3*(a1*a1+a2*a2) + d.z31*d.emsq
+		z2 = 6*(a1*a3+a2*a4) + z32*d.emsq
+		d.z3 = 3*(a3*a3+a4*a4) + d.z33*d.emsq
+		d.z11 = -6*a1*a5 + d.emsq*(-24*x1*x7-6*x3*x5)
+		z12 = -6*(a1*a6+a3*a5) + d.emsq*(-24*(x2*x7+x1*x8)-6*(x3*x6+x4*x5))
+		d.z13 = -6*a3*a6 + d.emsq*(-24*x2*x8-6*x4*x6)
+		d.z21 = 6*a2*a5 + d.emsq*(24*x1*x5-6*x3*x7)
+		z22 = 6*(a4*a5+a2*a6) + d.emsq*(24*(x2*x5+x1*x6)-6*(x4*x7+x3*x8))
+		d.z23 = 6*a4*a6 + d.emsq*(24*x2*x6-6*x4*x8)
+		d.z1 = d.z1 + d.z1 + betasq*d.z31
+		z2 = z2 + z2 + betasq*z32
+		d.z3 = d.z3 + d.z3 + betasq*d.z33
+		d.s3 = cc * xnoi
+		d.s2 = -0.5 * d.s3 / rtemsq
+		d.s4 = d.s3 * rtemsq
+		d.s1 = -15 * d.em * d.s4
+		d.s5 = x1*x3 + x2*x4
+		s6 = x2*x3 + x1*x4
+		s7 = x2*x4 - x1*x3
+
+		if lsflg == 1 {
+			d.ss1 = d.s1
+			d.ss2 = d.s2
+			d.ss3 = d.s3
+			d.ss4 = d.s4
+			d.ss5 = d.s5
+			ss6 = s6
+			ss7 = s7
+			d.sz1 = d.z1
+			sz2 = z2
+			d.sz3 = d.z3
+			d.sz11 = d.z11
+			sz12 = z12
+			d.sz13 = d.z13
+			d.sz21 = d.z21
+			sz22 = z22
+			d.sz23 = d.z23
+			d.sz31 = d.z31
+			sz32 = z32
+			d.sz33 = d.z33
+			zcosg = zcosgl
+			zsing = zsingl
+			zcosi = zcosil
+			zsini = zsinil
+			zcosh = zcoshl*cnodm + zsinhl*snodm
+			zsinh = snodm*zcoshl - cnodm*zsinhl
+			cc = c1l
+		}
+	}
+
+	s.zmol = math.Mod(4.7199672+0.22997150*day-gam, twoPi)
+	s.zmos = math.Mod(6.2565837+0.017201977*day, twoPi)
+
+	// Solar terms
+	s.se2 = 2 * d.ss1 * ss6
+	s.se3 = 2 * d.ss1 * ss7
+	s.si2 = 2 * d.ss2 * sz12
+	s.si3 = 2 * d.ss2 * (d.sz13 - d.sz11)
+	s.sl2 = -2 * d.ss3 * sz2
+	s.sl3 = -2 * d.ss3 * (d.sz3 - d.sz1)
+	s.sl4 = -2 * d.ss3 * (-21 - 9*d.emsq) * zes
+	s.sgh2 = 2 * d.ss4 * sz32
+	s.sgh3 = 2 * d.ss4 * (d.sz33 - d.sz31)
+	s.sgh4 = -18 * d.ss4 * zes
+	s.sh2 = -2 * d.ss2 * sz22
+	s.sh3 = -2 * d.ss2 * (d.sz23 - d.sz21)
+
+	// Lunar terms
+	s.ee2 = 2 * d.s1 * s6
+	s.e3 = 2 * d.s1 * s7
+	s.xi2 = 2 * d.s2 * z12
+	s.xi3 = 2 * d.s2 * (d.z13 - d.z11)
+	s.xl2 = -2 * d.s3 * z2
+	s.xl3 = -2 * d.s3 * (d.z3 - d.z1)
+	s.xl4 = -2 * d.s3 * (-21 - 9*d.emsq) * zel
+	s.xgh2 = 2 * d.s4 * z32
+	s.xgh3 = 2 * d.s4 * (d.z33 - d.z31)
+	s.xgh4 = -18 * d.s4 * zel
+	s.xh2 = -2 * d.s2 * z22
+	s.xh3 = -2 * d.s2 * (d.z23 - d.z21)
+
+	return d
+}
+
+// Applies the lunar-solar periodics to the mean elements at time t [min].
+func (s *SGP4) dpper(t float64, ep, inclp, nodep, argpp, mp *float64) {
+	const (
+		zns   float64 = 1.19459e-5
+		zes   float64 = 0.01675
+		znl   float64 = 1.5835218e-4
+		zel   float64 = 0.05490
+		twoPi float64 = 2 * math.Pi
+	)
+
+	// Time varying periodics
+	zm := s.zmos + zns*t
+	zf := zm + 2*zes*math.Sin(zm)
+	sinzf := math.Sin(zf)
+	f2 := 0.5*sinzf*sinzf - 0.25
+	f3 := -0.5 * sinzf * math.Cos(zf)
+	ses := s.se2*f2 + s.se3*f3
+	sis := s.si2*f2 + s.si3*f3
+	sls := s.sl2*f2 + s.sl3*f3 + s.sl4*sinzf
+	sghs := s.sgh2*f2 + s.sgh3*f3 + s.sgh4*sinzf
+	shs := s.sh2*f2 + s.sh3*f3
+
+	zm = s.zmol + znl*t
+	zf = zm + 2*zel*math.Sin(zm)
+	sinzf = math.Sin(zf)
+	f2 = 0.5*sinzf*sinzf - 0.25
+	f3 = -0.5 * sinzf * math.Cos(zf)
+	sel := s.ee2*f2 + s.e3*f3
+	sil := s.xi2*f2 + s.xi3*f3
+	sll := s.xl2*f2 + s.xl3*f3 + s.xl4*sinzf
+	sghl := s.xgh2*f2 + s.xgh3*f3 + s.xgh4*sinzf
+	shll := s.xh2*f2 + s.xh3*f3
+
+	pe := ses + sel - s.peo
+	pinc := sis + sil - s.pinco
+	pl := sls + sll - s.plo
+	pgh := sghs + sghl - s.pgho
+	ph := shs + shll - s.pho
+
+	*inclp = *inclp + pinc
+	*ep = *ep + pe
+	sinip := math.Sin(*inclp)
+	cosip := math.Cos(*inclp)
+
+	// Apply periodics directly, or with the Lyddane modification
+	// for low inclinations
+	if *inclp >= 0.2 {
+		ph = ph / sinip
+		pgh = pgh - cosip*ph
+		*argpp = *argpp + pgh
+		*nodep = *nodep + ph
+		*mp = *mp + pl
+		return
+	}
+
+	sinop := math.Sin(*nodep)
+	cosop := math.Cos(*nodep)
+	alfdp := sinip * sinop
+	betdp := sinip * cosop
+	dalf := ph*cosop + pinc*cosip*sinop
+	dbet := -ph*sinop + pinc*cosip*cosop
+	alfdp = alfdp + dalf
+	betdp = betdp + dbet
+	*nodep = math.Mod(*nodep, twoPi)
+	xls := *mp + *argpp + cosip**nodep
+	dls := pl + pgh - pinc**nodep*sinip
+	xls = xls + dls
+	xnoh := *nodep
+	*nodep = math.Atan2(alfdp, betdp)
+
+	if math.Abs(xnoh-*nodep) > math.Pi {
+		if *nodep < xnoh {
+			*nodep = *nodep + twoPi
+		} else {
+			*nodep = *nodep - twoPi
+		}
+	}
+
+	*mp = *mp + pl
+	*argpp = xls - *mp - cosip**nodep
+}
+
+// Initializes the deep-space secular rates and the resonance terms.
+// Accepts the values computed by dscom, time [min], time of the integrator
+// start [min], the secular rate of longitude of perigee [rad/min],
+// squared eccentricity and inclination at epoch [rad].
+func (s *SGP4) dsinit(d *deepSpaceCommon, t, tc, xpidot, eccsq, inclm float64) {
+	const (
+		q22    float64 = 1.7891679e-6
+		q31    float64 = 2.1460748e-6
+		q33    float64 = 2.2123015e-7
+		root22 float64 = 1.7891679e-6
+		root44 float64 = 7.3636953e-9
+		root54 float64 = 2.1765803e-9
+		rptim  float64 = 4.37526908801129966e-3
+		root32 float64 = 3.7393792e-7
+		root52 float64 = 1.1428639e-7
+		x2o3   float64 = 2.0 / 3.0
+		znl    float64 = 1.5835218e-4
+		zns    float64 = 1.19459e-5
+		twoPi  float64 = 2 * math.Pi
+	)
+
+	nm := d.nm
+	em := d.em
+	emsq := d.emsq
+	sinim := d.sinim
+	cosim := d.cosim
+
+	// Deep-space resonance classification
+	s.irez = 0
+	if nm < 0.0052359877 && nm > 0.0034906585 {
+		s.irez = 1
+	}
+	if nm >= 8.26e-3 && nm <= 9.24e-3 && em >= 0.5 {
+		s.irez = 2
+	}
+
+	// Solar terms
+	ses := d.ss1 * zns * d.ss5
+	sis := d.ss2 * zns * (d.sz11 + d.sz13)
+	sls := -zns * d.ss3 * (d.sz1 + d.sz3 - 14 - 6*emsq)
+	sghs := d.ss4 * zns * (d.sz31 + d.sz33 - 6)
+	shs := -zns * d.ss2 * (d.sz21 + d.sz23)
+
+	// Inclinations close to 0 or 180 degrees
+	if inclm < 5.2359877e-2 || inclm > math.Pi-5.2359877e-2 {
+		shs = 0
+	}
+	if sinim != 0 {
+		shs = shs / sinim
+	}
+	sgs := sghs - cosim*shs
+
+	// Lunar terms
+	s.dedt = ses + d.s1*znl*d.s5
+	s.didt = sis + d.s2*znl*(d.z11+d.z13)
+	s.dmdt = sls - znl*d.s3*(d.z1+d.z3-14-6*emsq)
+	sghl := d.s4 * znl * (d.z31 + d.z33 - 6)
+	shll := -znl * d.s2 * (d.z21 + d.z23)
+
+	if inclm < 5.2359877e-2 || inclm > math.Pi-5.2359877e-2 {
+		shll = 0
+	}
+	s.domdt = sgs + sghl
+	s.dnodt = shs
+	if sinim != 0 {
+		s.domdt = s.domdt - cosim/sinim*shll
+		s.dnodt = s.dnodt + shll/sinim
+	}
+
+	// Deep-space resonance effects
+	theta := math.Mod(s.gsto+tc*rptim, twoPi)
+
+	if s.irez == 0 {
+		return
+	}
+
+	aonv := math.Pow(nm/sgp4Xke, x2o3)
+
+	// Geopotential resonance for 12 hour orbits
+	if s.irez == 2 {
+		cosisq := cosim * cosim
+		em = s.ecco
+		emsq = eccsq
+		eoc := em * emsq
+		g201 := -0.306 - (em-0.64)*0.440
+
+		var g211, g310, g322, g410, g422, g520, g521, g532, g533 float64
+
+		if em <= 0.65 {
+			g211 = 3.616 - 13.2470*em + 16.2900*emsq
+			g310 = -19.302 + 117.3900*em - 228.4190*emsq + 156.5910*eoc
+			g322 = -18.9068 + 109.7927*em - 214.6334*emsq + 146.5816*eoc
+			g410 = -41.122 + 242.6940*em - 471.0940*emsq + 313.9530*eoc
+			g422 = -146.407 + 841.8800*em - 1629.014*emsq + 1083.4350*eoc
+			g520 = -532.114 + 3017.977*em - 5740.032*emsq + 3708.2760*eoc
+		} else {
+			g211 = -72.099 + 331.819*em - 508.738*emsq + 266.724*eoc
+			g310 = -346.844 + 1582.851*em - 2415.925*emsq + 1246.113*eoc
+			g322 = -342.585 + 1554.908*em - 2366.899*emsq + 1215.972*eoc
+			g410 = -1052.797 + 4758.686*em - 7193.992*emsq + 3651.957*eoc
+			g422 = -3581.690 + 16178.110*em - 24462.770*emsq + 12422.520*eoc
+			if em > 0.715 {
+				g520 = -5149.66 + 29936.92*em - 54087.36*emsq + 31324.56*eoc
+			} else {
+				g520 = 1464.74 - 4664.75*em + 3763.64*emsq
+			}
+		}
+
+		if em < 0.7 {
+			g533 = -919.22770 + 4988.6100*em - 9064.7700*emsq + 5542.21*eoc
+			g521 = -822.71072 + 4568.6173*em - 8491.4146*emsq + 5337.524*eoc
+			g532 = -853.66600 + 4690.2500*em - 8624.7700*emsq + 5341.4*eoc
+		} else {
+			g533 = -37995.780 + 161616.52*em - 229838.20*emsq + 109377.94*eoc
+			g521 = -51752.104 + 218913.95*em - 309468.16*emsq + 146349.42*eoc
+			g532 = -40023.880 + 170470.89*em - 242699.48*emsq + 115605.82*eoc
+		}
+
+		sini2 := sinim * sinim
+		f220 := 0.75 * (1 + 2*cosim + cosisq)
+		f221 := 1.5 * sini2
+		f321 := 1.875 * sinim * (1 - 2*cosim - 3*cosisq)
+		f322 := -1.875 * sinim * (1 + 2*cosim - 3*cosisq)
+		f441 := 35 * sini2 * f220
+		f442 := 39.3750 * sini2 * sini2
+		f522 := 9.84375 * sinim * (sini2*(1-2*cosim-5*cosisq) +
+			0.33333333*(-2+4*cosim+6*cosisq))
+		f523 := sinim * (4.92187512*sini2*(-2-4*cosim+10*cosisq) +
+			6.56250012*(1+2*cosim-3*cosisq))
+		f542 := 29.53125 * sinim * (2 - 8*cosim + cosisq*(-12+8*cosim+10*cosisq))
+		f543 := 29.53125 * sinim * (-2 - 8*cosim + cosisq*(12+8*cosim-10*cosisq))
+
+		xno2 := nm * nm
+		ainv2 := aonv * aonv
+		temp1 := 3 * xno2 * ainv2
+		temp := temp1 * root22
+		s.d2201 = temp * f220 * g201
+		s.d2211 = temp * f221 * g211
+		temp1 = temp1 * aonv
+		temp = temp1 * root32
+		s.d3210 = temp * f321 * g310
+		s.d3222 = temp * f322 * g322
+		temp1 = temp1 * aonv
+		temp = 2 * temp1 * root44
+		s.d4410 = temp * f441 * g410
+		s.d4422 = temp * f442 * g422
+		temp1 = temp1 * aonv
+		temp = temp1 * root52
+		s.d5220 = temp * f522 * g520
+		s.d5232 = temp * f523 * g532
+		temp = 2 * temp1 * root54
+		s.d5421 = temp * f542 * g521
+		s.d5433 = temp * f543 * g533
+		s.xlamo = math.Mod(s.mo+s.nodeo+s.nodeo-theta-theta, twoPi)
+		s.xfact = s.mdot + s.dmdt + 2*(s.nodedot+s.dnodt-rptim) - s.no
+		emsq = d.emsq
+	}
+
+	// Synchronous resonance terms
+	if s.irez == 1 {
+		g200 := 1 + emsq*(-2.5+0.8125*emsq)
+		g310 := 1 + 2*emsq
+		g300 := 1 + emsq*(-6+6.60937*emsq)
+		f220 := 0.75 * (1 + cosim) * (1 + cosim)
+		f311 := 0.9375*sinim*sinim*(1+3*cosim) - 0.75*(1+cosim)
+		f330 := 1 + cosim
+		f330 = 1.875 * f330 * f330 * f330
+		s.del1 = 3 * nm * nm * aonv * aonv
+		s.del2 = 2 * s.del1 * f220 * g200 * q22
+		s.del3 = 3 * s.del1 * f330 * g300 * q33 * aonv
+		s.del1 = s.del1 * f311 * g310 * q31 * aonv
+		s.xlamo = math.Mod(s.mo+s.nodeo+s.argpo-theta, twoPi)
+		s.xfact = s.mdot + xpidot - rptim + s.dmdt + s.domdt + s.dnodt - s.no
+	}
+
+	// Initialize the integrator
+	s.xli = s.xlamo
+	s.xni = s.no
+	s.atime = 0
+}
+
+// Applies the deep-space secular effects and integrates the resonance
+// terms to time t [min].
+func (s *SGP4) dspace(t float64, em, argpm, inclm, mm, nodem, nm *float64) {
+	const (
+		fasx2 float64 = 0.13130908
+		fasx4 float64 = 2.8843198
+		fasx6 float64 = 0.37448087
+		g22   float64 = 5.7686396
+		g32   float64 = 0.95240898
+		g44   float64 = 1.8014998
+		g52   float64 = 1.0508330
+		g54   float64 = 4.4108898
+		rptim float64 = 4.37526908801129966e-3
+		stepp float64 = 720
+		stepn float64 = -720
+		step2 float64 = 259200
+		twoPi float64 = 2 * math.Pi
+	)
+
+	theta := math.Mod(s.gsto+t*rptim, twoPi)
+
+	*em = *em + s.dedt*t
+	*inclm = *inclm + s.didt*t
+	*argpm = *argpm + s.domdt*t
+	*nodem = *nodem + s.dnodt*t
+	*mm = *mm + s.dmdt*t
+
+	if s.irez == 0 {
+		return
+	}
+
+	// Restart the integration from epoch if needed
+	if s.atime == 0 || t*s.atime <= 0 || math.Abs(t) < math.Abs(s.atime) {
+		s.atime = 0
+		s.xni = s.no
+		s.xli = s.xlamo
+	}
+
+	delt := stepp
+	if t <= 0 {
+		delt = stepn
+	}
+
+	var ft, xndt, xldot, xnddt float64
+
+	for {
+		if s.irez != 2 {
+			// Near-synchronous resonance terms
+			xndt = s.del1*math.Sin(s.xli-fasx2) + s.del2*math.Sin(2*(s.xli-fasx4)) +
+				s.del3*math.Sin(3*(s.xli-fasx6))
+			xldot = s.xni + s.xfact
+			xnddt = s.del1*math.Cos(s.xli-fasx2) + 2*s.del2*math.Cos(2*(s.xli-fasx4)) +
+				3*s.del3*math.Cos(3*(s.xli-fasx6))
+			xnddt = xnddt * xldot
+		} else {
+			// Near-half-day resonance terms
+			xomi := s.argpo + s.argpdot*s.atime
+			x2omi := xomi + xomi
+			x2li := s.xli + s.xli
+			xndt = s.d2201*math.Sin(x2omi+s.xli-g22) + s.d2211*math.Sin(s.xli-g22) +
+				s.d3210*math.Sin(xomi+s.xli-g32) + s.d3222*math.Sin(-xomi+s.xli-g32) +
+				s.d4410*math.Sin(x2omi+x2li-g44) + s.d4422*math.Sin(x2li-g44) +
+				s.d5220*math.Sin(xomi+s.xli-g52) + s.d5232*math.Sin(-xomi+s.xli-g52) +
+				s.d5421*math.Sin(xomi+x2li-g54) + s.d5433*math.Sin(-xomi+x2li-g54)
+			xldot = s.xni + s.xfact
+			xnddt = s.d2201*math.Cos(x2omi+s.xli-g22) + s.d2211*math.Cos(s.xli-g22) +
+				s.d3210*math.Cos(xomi+s.xli-g32) + s.d3222*math.Cos(-xomi+s.xli-g32) +
+				s.d5220*math.Cos(xomi+s.xli-g52) + s.d5232*math.Cos(-xomi+s.xli-g52) +
+				2*(s.d4410*math.Cos(x2omi+x2li-g44)+s.d4422*math.Cos(x2li-g44)+
+					s.d5421*math.Cos(xomi+x2li-g54)+s.d5433*math.Cos(-xomi+x2li-g54))
+			xnddt = xnddt * xldot
+		}
+
+		if math.Abs(t-s.atime) < stepp {
+			ft = t - s.atime
+			break
+		}
+
+		s.xli = s.xli + xldot*delt + xndt*step2
+		s.xni = s.xni + xndt*delt + xnddt*step2
+		s.atime = s.atime + delt
+	}
+
+	*nm = s.xni + xndt*ft + xnddt*ft*ft*0.5
+	xl := s.xli + xldot*ft + xndt*ft*ft*0.5
+
+	if s.irez != 1 {
+		*mm = xl - 2**nodem + 2*theta
+	} else {
+		*mm = xl - *nodem - *argpm + theta
+	}
+
+	dndt := *nm - s.no
+	*nm = s.no + dndt
+}
+
+// Returns the Greenwich Mean Sidereal Time [rad] at time t, according
+// to the IAU 1982 model used by SGP4.
+func GMST(t time.Time) float64 {
+	tut1 := (TimeToJDN(t) - 2451545) / 36525
+
+	// Seconds of sidereal time
+	sec := -6.2e-6*tut1*tut1*tut1 + 0.093104*tut1*tut1 + (876600*3600+8640184.812866)*tut1 + 67310.54841
+
+	gmst := math.Mod(Rad(sec/240), 2*math.Pi)
+	if gmst < 0 {
+		gmst += 2 * math.Pi
+	}
+
+	return gmst
+}
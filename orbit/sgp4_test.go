@@ -0,0 +1,114 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Propagation test case: TLE set, time since epoch and the expected state
+// vector published with the reference implementation [km, km/s].
+type sgp4Case struct {
+	Name, Line1, Line2 string
+
+	Tsince float64
+
+	R, V Vector
+}
+
+// Tests the propagator against the verification output of the reference
+// implementation (Vallado et al., 2006). Both the near-Earth and the deep-space
+// models are covered. Position must agree within 1 m, velocity within 1 mm/s.
+func TestPropagate(t *testing.T) {
+	cases := []sgp4Case{
+		{
+			"00005 near-Earth",
+			"1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753",
+			"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667",
+			0,
+			Vector{7022.46529266, -1400.08296755, 0.03995155},
+			Vector{1.893841015, 6.405893759, 4.534807250},
+		},
+		{
+			"00005 near-Earth",
+			"1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753",
+			"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667",
+			360,
+			Vector{-7154.03120202, -3783.17682504, -3536.19412294},
+			Vector{4.741887409, -4.151817765, -2.093935425},
+		},
+		{
+			"08195 deep-space, 12h resonance",
+			"1 08195U 75081A   06176.33215444  .00000099  00000-0  11873-3 0   813",
+			"2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656",
+			0,
+			Vector{2349.89483350, -14785.93811562, 0.02119378},
+			Vector{2.721488096, -3.256811655, 4.498416672},
+		},
+		{
+			"08195 deep-space, 12h resonance",
+			"1 08195U 75081A   06176.33215444  .00000099  00000-0  11873-3 0   813",
+			"2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656",
+			120,
+			Vector{15223.91713658, -17852.95881713, 25280.39558224},
+			Vector{1.079041732, 0.875187372, 2.485682813},
+		},
+	}
+
+	for _, c := range cases {
+		set, err := tle.ParseMatch(&tle.Match{Title: c.Name, Line1: c.Line1, Line2: c.Line2})
+		if err != nil {
+			t.Fatalf("%s: %v", c.Name, err)
+		}
+
+		s, err := NewSGP4(set)
+		if err != nil {
+			t.Fatalf("%s: %v", c.Name, err)
+		}
+
+		at := s.Epoch().Add(time.Duration(c.Tsince * float64(time.Minute)))
+
+		r, v, err := s.Propagate(at)
+		if err != nil {
+			t.Fatalf("%s at %.0f min: %v", c.Name, c.Tsince, err)
+		}
+
+		for i := range r {
+			if math.Abs(r[i]-c.R[i]*1e3) > 1 || math.Abs(v[i]-c.V[i]*1e3) > 1e-3 {
+				t.Fatalf("%s at %.0f min:\n got %v %v\nwant %v %v", c.Name, c.Tsince, r, v, c.R.Scale(1e3), c.V.Scale(1e3))
+			}
+		}
+	}
+}
+
+// Returns the ISS set shared by the tests.
+func testISS(t *testing.T) *tle.TLE {
+	t.Helper()
+
+	m := tle.Match{
+		Title: "ISS (ZARYA)",
+		Line1: "1 25544U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  9991",
+		Line2: "2 25544  51.6452  19.1428 0006828  17.5887  10.3753 15.49476744321309",
+	}
+
+	set, err := tle.ParseMatch(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return set
+}
+
+// Returns the propagator initialized from the ISS set shared by the tests.
+func testISSProp(t *testing.T) *SGP4 {
+	t.Helper()
+
+	prop, err := NewSGP4(testISS(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return prop
+}
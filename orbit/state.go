@@ -0,0 +1,144 @@
+package orbit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Returns the position and velocity of the object in the perifocal frame
+// at the time of the elements. The x axis points towards periapsis,
+// the y axis along the velocity at periapsis and the z axis along
+// the angular momentum. The state is calculated from the semi-major axis,
+// eccentricity and true anomaly.
+func (e *Elements) PerifocalState() (r, v Vector) {
+	p := e.SMa * (1 - e.Ecc*e.Ecc)
+
+	sin, cos := math.Sincos(Rad(e.TrA))
+	rad := p / (1 + e.Ecc*cos)
+	vel := math.Sqrt(G * e.DM / p)
+
+	r = Vector{rad * cos, rad * sin, 0}
+	v = Vector{-vel * sin, vel * (e.Ecc + cos), 0}
+
+	return r, v
+}
+
+// Returns the position and velocity of the object in the inertial frame
+// centered on the dominant body, at the time of the elements. The frame
+// is the one the elements refer to, i.e. TEME for TLE sets. The perifocal
+// state is rotated by the argument of periapsis, inclination and longitude
+// of ascending node.
+//
+// The elements are mean values, so the state differs from the one
+// propagated by SGP4 by the periodic perturbations, up to tens
+// of kilometers in low orbits.
+func (e *Elements) InertialState() (r, v Vector) {
+	r, v = e.PerifocalState()
+	return PerifocalToInertial(r, e.Inc, e.LAN, e.AgP), PerifocalToInertial(v, e.Inc, e.LAN, e.AgP)
+}
+
+// Rotates the vector from the perifocal frame to the inertial one, given
+// the inclination, longitude of ascending node and argument of periapsis.
+func PerifocalToInertial(u Vector, inc, lan, agp float64) Vector {
+	sinI, cosI := math.Sincos(Rad(inc))
+	sinO, cosO := math.Sincos(Rad(lan))
+	sinW, cosW := math.Sincos(Rad(agp))
+
+	// Columns of the rotation matrix: the directions of periapsis,
+	// of the velocity at periapsis and of the angular momentum
+	p := Vector{cosO*cosW - sinO*sinW*cosI, sinO*cosW + cosO*sinW*cosI, sinW * sinI}
+	q := Vector{-cosO*sinW - sinO*cosW*cosI, -sinO*sinW + cosO*cosW*cosI, cosW * sinI}
+	w := Vector{sinO * sinI, -cosO * sinI, cosI}
+
+	return Vector{
+		p[0]*u[0] + q[0]*u[1] + w[0]*u[2],
+		p[1]*u[0] + q[1]*u[1] + w[1]*u[2],
+		p[2]*u[0] + q[2]*u[1] + w[2]*u[2],
+	}
+}
+
+// Creates Elements struct from the position r [m] and velocity v [m/s]
+// in the inertial frame centered on the body, at time t [unix seconds].
+// This is the inverse of InertialState. The name, the TLE lines and
+// the warnings are left empty.
+//
+// The angles that are undefined are set to zero: the longitude
+// of ascending node of equatorial orbits, which is measured from the x axis
+// instead, and the argument of periapsis of circular orbits, whose true
+// anomaly is measured from the ascending node, or from the x axis
+// if the orbit is also equatorial. Returns an error if the orbit
+// is not elliptic.
+func CalculateElementsFromState(r, v Vector, t int64, b *Body) (*Elements, error) {
+	// Eccentricity and the sine of inclination below which the orbit
+	// is treated as circular or equatorial
+	const small float64 = 1e-10
+
+	rad, vel := r.Norm(), v.Norm()
+
+	// Angular momentum
+	h := r.Cross(v)
+
+	if rad == 0 || h.Norm() <= small*rad*vel {
+		return nil, errors.New("position and velocity do not define an orbit")
+	}
+
+	energy := vel*vel/2 - b.GM/rad
+
+	// Eccentricity vector, pointing towards periapsis
+	ev := r.Scale(vel*vel - b.GM/rad).Sub(v.Scale(r.Dot(v))).Scale(1 / b.GM)
+
+	if ecc := ev.Norm(); energy >= 0 || ecc >= 1 {
+		return nil, fmt.Errorf("orbit is not elliptic: Ecc %g", ecc)
+	}
+
+	var e Elements
+
+	e.Epoch = t
+	e.Time = t
+
+	e.Body = b.Name
+	e.Constants = b.Constants
+
+	e.DM = b.Mass()
+	e.DR = b.R
+	e.DA = b.A
+	e.DF = b.F
+	e.DJ2 = b.J2
+
+	e.SMa = -b.GM / (2 * energy)
+	e.T = 2 * math.Pi * math.Sqrt(math.Pow(e.SMa, 3)/b.GM)
+
+	n := h.Scale(1 / h.Norm())
+	e.Inc = Deg(math.Acos(math.Max(-1, math.Min(1, n[2]))))
+
+	// Direction the angles in the orbital plane are measured from:
+	// the ascending node, or the x axis if the orbit is equatorial
+	ref := Vector{1, 0, 0}
+
+	if node := (Vector{-h[1], h[0], 0}); node.Norm() > small*h.Norm() {
+		ref = node.Scale(1 / node.Norm())
+		e.LAN = planeAngle(Vector{1, 0, 0}, ref, Vector{0, 0, 1})
+	}
+
+	if ecc := ev.Norm(); ecc > small {
+		e.Ecc = ecc
+		e.AgP = planeAngle(ref, ev, n)
+		e.TrA = planeAngle(ev, r, n)
+	} else {
+		e.TrA = planeAngle(ref, r, n)
+	}
+
+	e.MnA = MeanAnomaly(e.Ecc, e.TrA)
+
+	e.calculateShape()
+	e.calculateAnomalies()
+
+	return &e, nil
+}
+
+// Returns the angle from vector u to w, measured counterclockwise about
+// the unit vector n, perpendicular to both of them.
+func planeAngle(u, w, n Vector) float64 {
+	return normalizeAngle(Deg(math.Atan2(u.Cross(w).Dot(n), u.Dot(w))))
+}
@@ -0,0 +1,127 @@
+package orbit
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Tests PerifocalState and InertialState methods. The magnitudes of both
+// states must match the radius and velocity of the elements, the position
+// must lie in the orbital plane at the argument of latitude, and must be
+// close to the one propagated by SGP4.
+func TestStateVectors(t *testing.T) {
+	const tolerance float64 = 1e-6
+
+	set := testISS(t)
+
+	e := CalculateElementsAround(set, WGS72)
+
+	pr, pv := e.PerifocalState()
+	r, v := e.InertialState()
+
+	for _, vec := range []Vector{pr, r} {
+		if math.Abs(vec.Norm()-e.R) > tolerance {
+			t.Fatalf("Radius %f, expected %f", vec.Norm(), e.R)
+		}
+	}
+
+	for _, vec := range []Vector{pv, v} {
+		if math.Abs(vec.Norm()-e.Vel) > tolerance {
+			t.Fatalf("Velocity %f, expected %f", vec.Norm(), e.Vel)
+		}
+	}
+
+	if z := e.R * math.Sin(Rad(e.Inc)) * math.Sin(Rad(e.AgP+e.TrA)); math.Abs(r[2]-z) > tolerance {
+		t.Fatalf("Z %f, expected %f", r[2], z)
+	}
+
+	prop := testISSProp(t)
+
+	sr, sv, err := prop.Propagate(time.Unix(e.Time, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := r.Sub(sr).Norm(); d > 30e3 {
+		t.Fatalf("Position differs from SGP4 by %f m", d)
+	}
+
+	if d := v.Sub(sv).Norm(); d > 30 {
+		t.Fatalf("Velocity differs from SGP4 by %f m/s", d)
+	}
+}
+
+// Tests CalculateElementsFromState function. The elements recovered
+// from the state must restore it, including the circular and equatorial
+// orbits, and none of their values may be NaN. The elements of the general
+// orbit must match the original ones.
+func TestElementsFromState(t *testing.T) {
+	const tolerance float64 = 1e-6
+
+	set := testISS(t)
+
+	cases := []struct {
+		name     string
+		ecc, inc float64
+	}{
+		{"general", set.L2.Ecc, set.L2.Inc},
+		{"circular", 0, set.L2.Inc},
+		{"equatorial", set.L2.Ecc, 0},
+		{"circular equatorial", 0, 0},
+		{"retrograde equatorial", set.L2.Ecc, 180},
+	}
+
+	for _, c := range cases {
+		orig := CalculateElementsAround(set, WGS72)
+		orig.Set("Ecc", c.ecc)
+		orig.Set("Inc", c.inc)
+
+		r, v := orig.InertialState()
+
+		e, err := CalculateElementsFromState(r, v, orig.Time, WGS72)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+
+		fields := reflect.ValueOf(e).Elem()
+		for i := 0; i < fields.NumField(); i++ {
+			if f, ok := fields.Field(i).Interface().(float64); ok && math.IsNaN(f) {
+				t.Fatalf("%s: %s is NaN", c.name, fields.Type().Field(i).Name)
+			}
+		}
+
+		er, ev := e.InertialState()
+		if dr, dv := er.Sub(r).Norm(), ev.Sub(v).Norm(); dr > 1e-3 || dv > 1e-6 {
+			t.Fatalf("%s: state differs by %g m, %g m/s", c.name, dr, dv)
+		}
+
+		if math.Abs(e.SMa-orig.SMa) > 1e-3 || math.Abs(e.Ecc-orig.Ecc) > tolerance || math.Abs(e.Inc-orig.Inc) > tolerance {
+			t.Fatalf("%s: SMa %f, Ecc %f, Inc %f", c.name, e.SMa, e.Ecc, e.Inc)
+		}
+
+		if c.name == "general" {
+			if math.Abs(e.LAN-orig.LAN) > tolerance || math.Abs(e.AgP-orig.AgP) > tolerance || math.Abs(e.MnA-orig.MnA) > tolerance {
+				t.Fatalf("%s: LAN %f, AgP %f, MnA %f", c.name, e.LAN, e.AgP, e.MnA)
+			}
+		}
+
+		// True longitude of retrograde orbits depends on the convention
+		if c.inc == 180 {
+			continue
+		}
+
+		if math.Abs(e.TrL-orig.TrL) > tolerance && math.Abs(math.Abs(e.TrL-orig.TrL)-360) > tolerance {
+			t.Fatalf("%s: TrL %f, expected %f", c.name, e.TrL, orig.TrL)
+		}
+	}
+
+	if _, err := CalculateElementsFromState(Vector{7e6, 0, 0}, Vector{0, 11e3, 0}, 0, WGS72); err == nil {
+		t.Fatal("Hyperbolic orbit accepted")
+	}
+
+	if _, err := CalculateElementsFromState(Vector{7e6, 0, 0}, Vector{1e3, 0, 0}, 0, WGS72); err == nil {
+		t.Fatal("Rectilinear orbit accepted")
+	}
+}
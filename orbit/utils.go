@@ -0,0 +1,40 @@
+package orbit
+
+import (
+	"math"
+	"time"
+)
+
+// Converts radians to degrees.
+func Deg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// Converts degrees to radians.
+func Rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Converts Unix Time to Julian Day Number.
+func UnixToJDN(unixSeconds int64) float64 {
+	return float64(unixSeconds)/86400 + 2440587.5
+}
+
+// Converts time.Time to Julian Day Number, preserving the fraction of a second.
+func TimeToJDN(t time.Time) float64 {
+	return (float64(t.Unix())+float64(t.Nanosecond())/1e9)/86400 + 2440587.5
+}
+
+// Converts Julian Day Number to Modified Julian Date.
+func JDNToMJD(jdn float64) float64 {
+	return jdn - 2400000.5
+}
+
+// Returns the angle in the range <0;360).
+func normalizeAngle(a float64) float64 {
+	a = math.Mod(a, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a
+}
@@ -0,0 +1,35 @@
+package orbit
+
+import "math"
+
+// Vector is a Cartesian vector in three-dimensional space.
+type Vector [3]float64
+
+// Returns the magnitude of the vector.
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Returns the dot product of the vectors v and u.
+func (v Vector) Dot(u Vector) float64 {
+	return v[0]*u[0] + v[1]*u[1] + v[2]*u[2]
+}
+
+// Returns the vector multiplied by scalar s.
+func (v Vector) Scale(s float64) Vector {
+	return Vector{v[0] * s, v[1] * s, v[2] * s}
+}
+
+// Returns the difference of the vectors v and u.
+func (v Vector) Sub(u Vector) Vector {
+	return Vector{v[0] - u[0], v[1] - u[1], v[2] - u[2]}
+}
+
+// Returns the cross product of the vectors v and u.
+func (v Vector) Cross(u Vector) Vector {
+	return Vector{
+		v[1]*u[2] - v[2]*u[1],
+		v[2]*u[0] - v[0]*u[2],
+		v[0]*u[1] - v[1]*u[0],
+	}
+}
@@ -0,0 +1,164 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Default age after which the cached response is fetched again.
+// CelesTrak updates the data roughly every two hours.
+const DEFAULT_CACHE_EXPIRY = 2 * time.Hour
+
+// The error returned by Cache.Fetch in offline mode if the response
+// to the query has not been cached.
+var ErrNotCached = errors.New("query not found in cache")
+
+// Cache stores the raw API responses on disk, so that repeated queries
+// do not hit the API and the data remains available without network.
+// A nil Cache is valid and always downloads the data.
+type Cache struct {
+	// Directory containing the cache entries
+	dir string
+
+	// Age after which the entry is fetched again
+	expiry time.Duration
+
+	// If true, the entries are served from cache only
+	offline bool
+}
+
+// Single cache entry saved as a JSON file.
+type cacheEntry struct {
+	// Key of the entry, e.g. the query
+	Query string `json:"query"`
+
+	// Time the response was downloaded
+	Fetched time.Time `json:"fetched"`
+
+	// Raw response body
+	Data string `json:"data"`
+}
+
+// Creates a new cache in dir, creating the directory if needed.
+func NewCache(dir string, expiry time.Duration, offline bool) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Cache{dir: dir, expiry: expiry, offline: offline}, nil
+}
+
+// Returns the default cache directory within the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "myrtle"), nil
+}
+
+// Returns true if the data is served from cache only.
+func (c *Cache) Offline() bool {
+	return c != nil && c.offline
+}
+
+// Returns the response stored under the key, e.g. the query, along
+// with the time it was downloaded. Entries younger than the expiry age
+// are served from cache. Expired entries are downloaded again, unless
+// the cache is offline or the download fails, in which case the expired
+// entry is returned. If the server responds with an error, ResponseError
+// is returned instead, because the query itself may be at fault, and so is
+// ErrNoData if the server found nothing. The entry is stored only if
+// the download succeeds, so download may reject the invalid responses.
+func (c *Cache) Fetch(key string, download func() ([]byte, error)) ([]byte, time.Time, error) {
+	if c == nil {
+		data, err := download()
+		return data, time.Now(), err
+	}
+
+	entry, cacheErr := c.load(key)
+
+	if c.offline {
+		if cacheErr != nil {
+			return nil, time.Time{}, fmt.Errorf("%w: %s", ErrNotCached, key)
+		}
+		return []byte(entry.Data), entry.Fetched, nil
+	}
+
+	if cacheErr == nil && time.Since(entry.Fetched) < c.expiry {
+		return []byte(entry.Data), entry.Fetched, nil
+	}
+
+	data, err := download()
+	if err != nil {
+		var respErr *ResponseError
+		if cacheErr != nil || errors.As(err, &respErr) || errors.Is(err, ErrNoData) {
+			return nil, time.Time{}, err
+		}
+		return []byte(entry.Data), entry.Fetched, nil
+	}
+
+	fetched := time.Now()
+
+	// Failure to store the entry affects only the subsequent queries
+	c.store(&cacheEntry{Query: key, Fetched: fetched, Data: string(data)})
+
+	return data, fetched, nil
+}
+
+// Reads the entry stored under the key from disk.
+func (c *Cache) load(key string) (*cacheEntry, error) {
+	stream, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+
+	if err := json.Unmarshal(stream, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Writes the entry to disk. The file is replaced atomically, so that
+// an interrupted write does not corrupt the existing entry.
+func (c *Cache) store(entry *cacheEntry) error {
+	stream, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(entry.Query)
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(stream); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Returns the path of the entry file. The file is named after the hash
+// of the key, so that the keys of any length and letter case are kept apart.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
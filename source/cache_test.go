@@ -0,0 +1,124 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Stub transport counting the requests. If body is empty,
+// every request fails. If status is set, the server responds with it
+// instead of 200 OK.
+type stubTransport struct {
+	body     string
+	status   int
+	requests int
+}
+
+func (st *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	st.requests++
+
+	if len(st.body) == 0 {
+		return nil, errors.New("network unreachable")
+	}
+
+	status := http.StatusOK
+	if st.status != 0 {
+		status = st.status
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       io.NopCloser(bytes.NewBufferString(st.body)),
+		Request:    req,
+	}, nil
+}
+
+// Tests Cache.Fetch method. Fresh entries must be served without requests,
+// expired entries must be downloaded again or served if the download fails,
+// the error response of the server must be reported instead of the expired
+// entry, and the offline cache must never send requests.
+func TestCacheFetch(t *testing.T) {
+	const query = "https://celestrak.com/NORAD/elements/gp.php?NAME=ISS"
+
+	st := &stubTransport{body: "first"}
+	client := &http.Client{Transport: st}
+
+	dl := func(url string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return download(client, url)
+		}
+	}
+
+	cache, err := NewCache(t.TempDir(), time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetch := func(c *Cache, want string) time.Time {
+		t.Helper()
+
+		data, fetched, err := c.Fetch(query, dl(query))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Fatalf("Expected %q, got %q", want, data)
+		}
+		return fetched
+	}
+
+	fetched := fetch(cache, "first")
+
+	st.body = "second"
+	if fetch(cache, "first"); st.requests != 1 {
+		t.Fatalf("Fresh entry downloaded again: %d requests", st.requests)
+	}
+
+	// Keys differing in letter case and the keys too long for a file name
+	// must be stored apart
+	for _, key := range []string{strings.ToLower(query), query + strings.Repeat("&NAME=ISS", 40)} {
+		if data, _, err := cache.Fetch(key, dl(key)); err != nil || string(data) != "second" {
+			t.Fatalf("Key %s: %q, %v", key, data, err)
+		}
+	}
+
+	if fetch(cache, "first"); st.requests != 3 {
+		t.Fatalf("Entry overwritten: %d requests", st.requests)
+	}
+
+	cache.expiry = 0
+
+	if fetch(cache, "second"); st.requests != 4 {
+		t.Fatalf("Expired entry not downloaded: %d requests", st.requests)
+	}
+
+	st.body = ""
+	if fetch(cache, "second").Before(fetched) {
+		t.Fatal("Expired entry not served when download failed")
+	}
+
+	st.body, st.status = "Try again later", http.StatusServiceUnavailable
+	var respErr *ResponseError
+	if _, _, err := cache.Fetch(query, dl(query)); !errors.As(err, &respErr) {
+		t.Fatalf("Expected ResponseError, got %v", err)
+	}
+
+	offline := &Cache{dir: cache.dir, expiry: 0, offline: true}
+	requests := st.requests
+
+	if fetch(offline, "second"); st.requests != requests {
+		t.Fatal("Offline cache sent a request")
+	}
+
+	other := "https://celestrak.com/NORAD/elements/gp.php?CATNR=25544"
+	if _, _, err := offline.Fetch(other, dl(other)); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("Expected ErrNotCached, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// DirSource serves the element sets from the files in a local directory.
+// The files contain the sets in TLE format, or in one of the OMM formats
+// if their names end with .json, .xml, .csv or .kvn extension. The name
+// of the file without the extension is the name of the group or special
+// dataset, e.g. stations.txt or gpz.json.
+type DirSource struct {
+	// Directory containing the files
+	dir string
+}
+
+// Creates the source serving the sets from the files in dir.
+func NewDirSource(dir string) (*DirSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+
+	return &DirSource{dir: dir}, nil
+}
+
+// Searches the files for the sets matching the value of the specified kind,
+// the same way the API does. Names and groups are matched regardless
+// of the letter case, names and international designators by their part.
+// If the same object is found in more than one file, the first set is
+// returned. The returned time is the modification time of the most recently
+// modified file that was searched.
+func (s *DirSource) Query(kind QueryKind, value string) ([]*tle.Match, time.Time, error) {
+	value, err := normalizeQuery(kind, value)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var (
+		matches  []*tle.Match
+		modified time.Time
+	)
+
+	seen := make(map[string]bool)
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		ext := filepath.Ext(name)
+
+		if (kind == QUERY_GROUP || kind == QUERY_SPECIAL) && !strings.EqualFold(strings.TrimSuffix(name, ext), value) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		stream, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		found, err := parseFile(stream, fileFormat(ext))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %w", name, err)
+		}
+
+		if info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+
+		for _, m := range found {
+			if seen[m.GetCatNum()] || !matchesQuery(m, kind, value) {
+				continue
+			}
+
+			seen[m.GetCatNum()] = true
+			matches = append(matches, m)
+		}
+	}
+
+	return matches, modified, nil
+}
+
+// Parses the content of a local file. The files that contain no sets,
+// including the saved error responses of the API, yield no matches.
+func parseFile(stream []byte, format tle.Format) ([]*tle.Match, error) {
+	matches, err := ParseData(stream, format)
+
+	var respErr *ResponseError
+	if errors.Is(err, ErrNoData) || errors.As(err, &respErr) {
+		return nil, nil
+	}
+
+	return matches, err
+}
+
+// Returns the format of the file given by its extension. Files with
+// unknown extensions are assumed to contain TLE sets.
+func fileFormat(ext string) tle.Format {
+	f, err := tle.ParseFormat(strings.TrimPrefix(ext, "."))
+	if err != nil {
+		return tle.FORMAT_TLE
+	}
+	return f
+}
+
+// Returns true if the set matches the normalized query value of the specified
+// kind. Groups and special datasets are matched by the file name, so every set
+// matches them.
+func matchesQuery(m *tle.Match, kind QueryKind, value string) bool {
+	switch kind {
+	case QUERY_NAME:
+		return strings.Contains(strings.ToUpper(m.Title), strings.ToUpper(value))
+	case QUERY_CATNR:
+		num := strings.TrimRightFunc(m.GetCatNum(), func(r rune) bool { return r < '0' || r > '9' })
+		a, errA := strconv.Atoi(num)
+		b, errB := strconv.Atoi(value)
+		return errA == nil && errB == nil && a == b
+	case QUERY_INTDES:
+		set, err := tle.ParseMatch(m)
+		if err != nil {
+			return false
+		}
+		id := set.L1.IntlDesig
+		desig := fmt.Sprintf("%d-%03d%s", id.LaunchYear, id.LaunchNum, id.LaunchComp)
+		return strings.HasPrefix(desig, strings.ToUpper(value))
+	default:
+		return true
+	}
+}
@@ -0,0 +1,78 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Tests DirSource.Query method. The sets must be matched the same way
+// as by the API: by part of the name, catalog number, international
+// designator or the name of the file for groups. Sets found in more than
+// one file must be returned once.
+func TestDirSourceQuery(t *testing.T) {
+	const (
+		iss       = issSet
+		swisscube = "SWISSCUBE               \n" +
+			"1 35932U 09051B   22013.55765441  .00000268  00000+0  71136-4 0  9999\n" +
+			"2 35932  98.5837 225.0161 0007892 155.7494 204.4076 14.56655971653547\n"
+	)
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"stations.txt": iss,
+		"cubesat.tle":  swisscube + iss,
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src, err := NewDirSource(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		kind  QueryKind
+		value string
+		want  []string
+	}{
+		{QUERY_NAME, "zarya", []string{"ISS (ZARYA)"}},
+		{QUERY_NAME, "iss", []string{"SWISSCUBE", "ISS (ZARYA)"}},
+		{QUERY_CATNR, "35932", []string{"SWISSCUBE"}},
+		{QUERY_CATNR, "25544U", []string{"ISS (ZARYA)"}},
+		{QUERY_INTDES, "1998-067", []string{"ISS (ZARYA)"}},
+		{QUERY_INTDES, "2009-051B", []string{"SWISSCUBE"}},
+		{QUERY_GROUP, "STATIONS", []string{"ISS (ZARYA)"}},
+		{QUERY_GROUP, "weather", nil},
+	}
+
+	for _, c := range cases {
+		matches, modified, err := src.Query(c.kind, c.value)
+		if err != nil {
+			t.Fatalf("%s=%s: %v", c.kind, c.value, err)
+		}
+
+		if modified.IsZero() && c.want != nil {
+			t.Fatalf("%s=%s: modification time not set", c.kind, c.value)
+		}
+
+		if len(matches) != len(c.want) {
+			t.Fatalf("%s=%s: %d matches, expected %d", c.kind, c.value, len(matches), len(c.want))
+		}
+
+		for i := range matches {
+			if title := matches[i].Title; title[:len(c.want[i])] != c.want[i] {
+				t.Fatalf("%s=%s: match %d is %q, expected %q", c.kind, c.value, i, title, c.want[i])
+			}
+		}
+	}
+
+	if _, err := NewDirSource(filepath.Join(dir, "stations.txt")); err == nil {
+		t.Fatal("File accepted as directory")
+	}
+}
@@ -0,0 +1,82 @@
+package source
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// FileSource serves the element sets read from a single file or a stream,
+// e.g. stdin. The sets may be two-line or three-line. Queries are matched
+// the same way as by DirSource, except for the groups and special datasets,
+// which match every set.
+type FileSource struct {
+	// Sets read from the file
+	matches []*tle.Match
+
+	// Modification time of the file, zero for streams
+	modified time.Time
+}
+
+// Reads the sets from the file. The file contains TLE sets, or OMM
+// if its name ends with .json, .xml, .csv or .kvn extension.
+func NewFileSource(name string) (*FileSource, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := parseFile(stream, fileFormat(filepath.Ext(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSource{matches: matches, modified: info.ModTime()}, nil
+}
+
+// Reads the sets in the specified format from r until EOF.
+func NewStreamSource(r io.Reader, format tle.Format) (*FileSource, error) {
+	stream, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := parseFile(stream, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSource{matches: matches}, nil
+}
+
+// Returns all the sets read, along with the modification time of the file.
+func (s *FileSource) Matches() ([]*tle.Match, time.Time) {
+	return s.matches, s.modified
+}
+
+// Returns the sets matching the value of the specified kind, along with
+// the modification time of the file.
+func (s *FileSource) Query(kind QueryKind, value string) ([]*tle.Match, time.Time, error) {
+	value, err := normalizeQuery(kind, value)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var matches []*tle.Match
+
+	for _, m := range s.matches {
+		if matchesQuery(m, kind, value) {
+			matches = append(matches, m)
+		}
+	}
+
+	return matches, s.modified, nil
+}
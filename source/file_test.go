@@ -0,0 +1,55 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Tests NewFileSource and NewStreamSource functions. All the sets must be
+// read from the file or the stream, and queries must select among them.
+func TestFileSource(t *testing.T) {
+	const sets = issLine1 + "\n" + issLine2 + "\n" +
+		"SWISSCUBE\n" +
+		"1 35932U 09051B   22013.55765441  .00000268  00000+0  71136-4 0  9999\n" +
+		"2 35932  98.5837 225.0161 0007892 155.7494 204.4076 14.56655971653547\n"
+
+	name := filepath.Join(t.TempDir(), "partner.tle")
+
+	if err := os.WriteFile(name, []byte(sets), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := NewFileSource(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := NewStreamSource(strings.NewReader(sets), tle.FORMAT_TLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range []*FileSource{file, stream} {
+		all, _ := src.Matches()
+		if len(all) != 2 {
+			t.Fatalf("%d sets read, expected 2", len(all))
+		}
+
+		matches, _, err := src.Query(QUERY_CATNR, "35932")
+		if err != nil || len(matches) != 1 || matches[0].Title != "SWISSCUBE" {
+			t.Fatalf("Query by catalog number failed: %v, %v", matches, err)
+		}
+	}
+
+	if _, modified := file.Matches(); modified.IsZero() {
+		t.Fatal("Modification time of the file not set")
+	}
+
+	if _, err := NewFileSource(name + ".missing"); err == nil {
+		t.Fatal("Missing file accepted")
+	}
+}
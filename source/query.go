@@ -0,0 +1,287 @@
+// Package source queries CelesTrak, other element servers and local
+// directories for element sets and caches the downloaded responses.
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+const (
+	// Base URL of the CelesTrak API, the query is appended to it
+	CELESTRAK_URL string = "https://celestrak.com/NORAD/elements/gp.php"
+
+	// Response of the API if no object matches the query
+	NO_DATA_RESPONSE string = "No GP data found"
+
+	// Minimum query length - to avoid downloading of half the database
+	MIN_QLEN int = 3
+)
+
+// Kind of the value the API is queried with.
+type QueryKind uint8
+
+const (
+	// Object name
+	QUERY_NAME QueryKind = iota
+
+	// NORAD catalog number
+	QUERY_CATNR
+
+	// International designator, e.g. 1998-067 for the whole launch or 1998-067A
+	QUERY_INTDES
+
+	// Group of objects, e.g. stations, starlink, weather
+	QUERY_GROUP
+
+	// Special dataset, e.g. gpz, decaying
+	QUERY_SPECIAL
+)
+
+// Returns the name of the query parameter.
+func (k QueryKind) String() string {
+	switch k {
+	case QUERY_CATNR:
+		return "CATNR"
+	case QUERY_INTDES:
+		return "INTDES"
+	case QUERY_GROUP:
+		return "GROUP"
+	case QUERY_SPECIAL:
+		return "SPECIAL"
+	default:
+		return "NAME"
+	}
+}
+
+var (
+	// The error returned from the Query methods if the query value is of zero length.
+	ErrEmptyQuery = errors.New("query value is empty")
+
+	// The error returned from the Query methods if the query value is shorter than MIN_QLEN
+	ErrShortQuery = errors.New("query value is too short")
+
+	// The error returned from the parsing functions if the API responded
+	// with NO_DATA_RESPONSE. The Query methods return no matches instead.
+	ErrNoData = errors.New("no data found")
+)
+
+// ResponseError is returned if the API responds with a status other than
+// 200 OK, or with an error message instead of the data.
+type ResponseError struct {
+	// Status of the response, e.g. "404 Not Found". Empty if the status
+	// is 200 OK.
+	Status string
+
+	// Message contained in the response body, shortened to MAX_MESSAGE_LEN
+	Message string
+}
+
+// Maximum length of ResponseError.Message
+const MAX_MESSAGE_LEN int = 120
+
+func (e *ResponseError) Error() string {
+	switch {
+	case len(e.Status) > 0 && len(e.Message) > 0:
+		return fmt.Sprintf("unexpected response status: %s: %s", e.Status, e.Message)
+	case len(e.Status) > 0:
+		return "unexpected response status: " + e.Status
+	default:
+		return "unexpected response: " + e.Message
+	}
+}
+
+// Source provides the element sets matching the queries.
+type Source interface {
+	// Returns the sets matching the value of the specified kind, e.g. object
+	// name, NORAD catalogue number or group name, along with the time
+	// the data was obtained.
+	Query(kind QueryKind, value string) ([]*tle.Match, time.Time, error)
+}
+
+// HTTPSource queries the API compatible with CelesTrak's GP query interface,
+// e.g. CelesTrak itself, its mirror or a proxy.
+type HTTPSource struct {
+	// HTTP client used to fetch data
+	client *http.Client
+
+	// URL the query is appended to
+	baseURL string
+
+	// Disk cache of the responses, may be nil
+	cache *Cache
+
+	// Format of the requested data
+	format tle.Format
+}
+
+// Creates the source querying the API at baseURL, e.g.
+// "https://celestrak.com/NORAD/elements/gp.php". The data is requested
+// in the specified format and served from cache if possible.
+// If cache is nil, the data is downloaded on every query.
+func NewHTTPSource(client *http.Client, baseURL string, cache *Cache, format tle.Format) *HTTPSource {
+	return &HTTPSource{client: client, baseURL: baseURL, cache: cache, format: format}
+}
+
+// Creates the source querying CelesTrak.
+func NewCelesTrak(client *http.Client, cache *Cache, format tle.Format) *HTTPSource {
+	return NewHTTPSource(client, CELESTRAK_URL, cache, format)
+}
+
+// Queries the API with the value of the specified kind. The result is a list
+// of pointers to Match structs containing results, along with the time
+// the data was downloaded.
+func (s *HTTPSource) Query(kind QueryKind, value string) ([]*tle.Match, time.Time, error) {
+	value, err := normalizeQuery(kind, value)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	sep := "?"
+	if strings.Contains(s.baseURL, "?") {
+		sep = "&"
+	}
+
+	addr := s.baseURL + sep + kind.String() + "=" + url.QueryEscape(value) + "&FORMAT=" + string(s.format)
+
+	// The API does not distinguish the letter case of the value,
+	// so the queries differing only in case share the cache entry
+	key := s.baseURL + " " + kind.String() + " " + strings.ToUpper(value) + " " + string(s.format)
+
+	// Only the valid responses are stored in cache
+	stream, fetched, err := s.cache.Fetch(key, func() ([]byte, error) {
+		stream, err := download(s.client, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := ParseData(stream, s.format); err != nil {
+			return nil, err
+		}
+
+		return stream, nil
+	})
+	if errors.Is(err, ErrNoData) {
+		return nil, time.Now(), nil
+	} else if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	matches, err := ParseData(stream, s.format)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return matches, fetched, nil
+}
+
+// Validates the length of the query value. Catalog numbers are stripped
+// of the classification letter and decoded from Alpha-5 format.
+func normalizeQuery(kind QueryKind, value string) (string, error) {
+	if len(value) == 0 {
+		return "", ErrEmptyQuery
+	} else if len(value) < MIN_QLEN {
+		return "", ErrShortQuery
+	}
+
+	if kind == QUERY_CATNR {
+		runes := []rune(value)
+		if unicode.IsLetter(runes[len(runes)-1]) {
+			value = string(runes[:len(runes)-1])
+		}
+
+		if n, err := tle.DecodeCatNum(value); err == nil {
+			value = strconv.Itoa(n)
+		}
+	}
+
+	return value, nil
+}
+
+// Downloads the response from the URL.
+func download(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return readResponse(resp)
+}
+
+// Reads the body of the response. Returns ResponseError if the status
+// is other than 200 OK.
+func readResponse(resp *http.Response) ([]byte, error) {
+	stream, err := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := errorMessage(stream)
+		return nil, &ResponseError{Status: resp.Status, Message: msg}
+	}
+
+	return stream, err
+}
+
+// Parses the response from the API and returns the results as a list of pointers to Match structs.
+func ParseQuery(resp *http.Response) ([]*tle.Match, error) {
+	stream, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseData(stream, tle.FORMAT_TLE)
+}
+
+// Parses the raw response body in the specified format and returns
+// the results as a list of pointers to Match structs. Returns ErrNoData
+// if nothing was found, and ResponseError if the body contains an error
+// message instead of the data.
+func ParseData(stream []byte, format tle.Format) ([]*tle.Match, error) {
+	// The API responds in plain text regardless of the format
+	// if nothing was found
+	if strings.TrimSpace(string(stream)) == NO_DATA_RESPONSE {
+		return nil, ErrNoData
+	}
+
+	if format != tle.FORMAT_TLE {
+		matches, err := tle.ParseOMM(stream, format)
+		if msg, ok := errorMessage(stream); err != nil && ok {
+			return nil, &ResponseError{Message: msg}
+		}
+		return matches, err
+	}
+
+	matches := tle.Parse(stream)
+	if msg, ok := errorMessage(stream); len(matches) == 0 && ok {
+		return nil, &ResponseError{Message: msg}
+	}
+
+	return matches, nil
+}
+
+// Returns the body as the error message, shortened to MAX_MESSAGE_LEN.
+// The boolean is false if the body does not look like a message:
+// it is empty, spans multiple lines or contains markup.
+func errorMessage(stream []byte) (string, bool) {
+	text := strings.TrimSpace(string(stream))
+
+	if len(text) == 0 || strings.ContainsAny(text, "\n<>{}[]") {
+		return "", false
+	}
+
+	runes := []rune(text)
+	if len(runes) > MAX_MESSAGE_LEN {
+		text = string(runes[:MAX_MESSAGE_LEN-3]) + "..."
+	}
+
+	return text, true
+}
@@ -0,0 +1,205 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zedran/myrtle/tle"
+)
+
+// Lines of the ISS set shared by the tests.
+const (
+	issLine1 = "1 25544U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  9991"
+	issLine2 = "2 25544  51.6452  19.1428 0006828  17.5887  10.3753 15.49476744321309"
+
+	// Three-line set with the title padded as served by the API
+	issSet = "ISS (ZARYA)             \n" + issLine1 + "\n" + issLine2 + "\n"
+)
+
+// Tests the ParseQuery functions. The function should return the slice
+// of non-nil Match struct pointers equal in length to the number of sets
+// in a sample string. The length of Lines 1 and 2 must be 69 chars long.
+func TestParseQuery(t *testing.T) {
+	const LINE_LEN = 69
+
+	// Test sample must contain a newline count equal to lines count
+	// (hence LF at the end)
+	const sample = `ISS (ZARYA)             
+1 25544U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  9991
+2 25544  51.6452  19.1428 0006828  17.5887  10.3753 15.49476744321309
+SWISSCUBE               
+1 35932U 09051B   22013.55765441  .00000268  00000+0  71136-4 0  9999
+2 35932  98.5837 225.0161 0007892 155.7494 204.4076 14.56655971653547
+`
+
+	// Mock response has CRLF line breaks, as served by the API
+	mockResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBuffer([]byte(strings.Replace(sample, "\n", "\r\n", -1)))),
+	}
+	defer mockResp.Body.Close()
+
+	output, err := ParseQuery(mockResp)
+	if err != nil {
+		t.Fatalf("Error when parsing response: %v", err)
+	}
+
+	// Every set is a 3-liner, therefore
+	if len(output) != strings.Count(sample, "\n")/3 {
+		t.Fatalf("Improper output slice length: %d", len(output))
+	}
+
+	// Testing the length of Lines 1 and 2
+	for i := range output {
+		if len(output[i].Line1) != LINE_LEN {
+			t.Fatalf("Improper line 1 length for struct %d", i+1)
+		} else if len(output[i].Line2) != LINE_LEN {
+			t.Fatalf("Improper line 2 length for struct %d", i+1)
+		}
+	}
+}
+
+// Tests ParseData function with the response to a query that matched nothing.
+// ErrNoData is expected regardless of the format.
+func TestParseNoData(t *testing.T) {
+	formats := []tle.Format{tle.FORMAT_TLE, tle.FORMAT_JSON, tle.FORMAT_XML, tle.FORMAT_CSV, tle.FORMAT_KVN}
+
+	for _, f := range formats {
+		matches, err := ParseData([]byte(NO_DATA_RESPONSE+"\r\n"), f)
+		if !errors.Is(err, ErrNoData) || len(matches) != 0 {
+			t.Fatalf("%s: %d matches, %v", f, len(matches), err)
+		}
+	}
+}
+
+// Tests ParseQuery function with the error responses. ResponseError must be
+// returned for statuses other than 200 OK and for the error messages.
+func TestParseQueryErrors(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   ResponseError
+	}{
+		{http.StatusNotFound, "<html><body>Not Found</body></html>", ResponseError{Status: "404 Not Found"}},
+		{http.StatusServiceUnavailable, "Try again later", ResponseError{Status: "503 Service Unavailable", Message: "Try again later"}},
+		{http.StatusOK, "Invalid query: \"NAME=\"\r\n", ResponseError{Message: "Invalid query: \"NAME=\""}},
+		{http.StatusOK, strings.Repeat("x", 200), ResponseError{Message: strings.Repeat("x", MAX_MESSAGE_LEN-3) + "..."}},
+	}
+
+	for _, c := range cases {
+		resp := &http.Response{
+			StatusCode: c.status,
+			Status:     fmt.Sprintf("%d %s", c.status, http.StatusText(c.status)),
+			Body:       io.NopCloser(strings.NewReader(c.body)),
+		}
+
+		_, err := ParseQuery(resp)
+
+		var respErr *ResponseError
+		if !errors.As(err, &respErr) || *respErr != c.want {
+			t.Fatalf("%q: got %#v, expected %#v", c.body, err, c.want)
+		}
+	}
+}
+
+// Tests HTTPSource.Query method against a stub server. The query must be
+// appended to the base URL, also if it already contains parameters.
+// The response to a query that matched nothing must yield no matches,
+// and the error status must be reported as ResponseError. The value must
+// be escaped, so that the server receives it unchanged, and the queries
+// differing only in letter case must share the cache entry. The responses
+// without data must not be cached.
+func TestHTTPSourceQuery(t *testing.T) {
+	sample := strings.ReplaceAll(issSet, "\n", "\r\n")
+
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.String())
+		io.WriteString(w, sample)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		base, want string
+	}{
+		{server.URL + "/gp.php", "/gp.php?CATNR=25544&FORMAT=TLE"},
+		{server.URL + "/proxy?key=abc", "/proxy?key=abc&CATNR=25544&FORMAT=TLE"},
+	}
+
+	for _, c := range cases {
+		src := NewHTTPSource(server.Client(), c.base, nil, tle.FORMAT_TLE)
+
+		matches, _, err := src.Query(QUERY_CATNR, "25544U")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(matches) != 1 || requests[len(requests)-1] != c.want {
+			t.Fatalf("%d matches, request %s, expected %s", len(matches), requests[len(requests)-1], c.want)
+		}
+	}
+
+	// The value must reach the server intact
+	const name = "ISS (ZARYA) & #1"
+	if _, _, err := NewHTTPSource(server.Client(), server.URL, nil, tle.FORMAT_TLE).Query(QUERY_NAME, name); err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := url.ParseQuery(strings.TrimPrefix(requests[len(requests)-1], "/?")); q.Get("NAME") != name || q.Get("FORMAT") != "TLE" {
+		t.Fatalf("Name %q, format %q received, request %s", q.Get("NAME"), q.Get("FORMAT"), requests[len(requests)-1])
+	}
+
+	if _, _, err := NewHTTPSource(server.Client(), server.URL, nil, tle.FORMAT_TLE).Query(QUERY_NAME, "IS"); err != ErrShortQuery {
+		t.Fatalf("Expected ErrShortQuery, got %v", err)
+	}
+
+	cache, err := NewCache(t.TempDir(), time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Queries differing only in letter case must share the cache entry
+	cached := NewHTTPSource(server.Client(), server.URL, cache, tle.FORMAT_TLE)
+	for _, name := range []string{"iss (zarya)", "ISS (ZARYA)"} {
+		if matches, _, err := cached.Query(QUERY_NAME, name); err != nil || len(matches) != 1 {
+			t.Fatalf("Cached %q: %d matches, %v", name, len(matches), err)
+		}
+	}
+	if r := requests[len(requests)-1]; r != "/?NAME=iss+%28zarya%29&FORMAT=TLE" {
+		t.Fatalf("Entry not shared, last request %s", r)
+	}
+
+	var emptyRequests int
+
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		emptyRequests++
+		if r.URL.Query().Get("NAME") == "" {
+			http.Error(w, "Internal error", http.StatusInternalServerError)
+			return
+		}
+		io.WriteString(w, NO_DATA_RESPONSE)
+	}))
+	defer empty.Close()
+
+	src := NewHTTPSource(empty.Client(), empty.URL, cache, tle.FORMAT_JSON)
+
+	// The response without data must not be cached
+	for i := 1; i <= 2; i++ {
+		if matches, _, err := src.Query(QUERY_NAME, "NOTHING"); err != nil || len(matches) != 0 || emptyRequests != i {
+			t.Fatalf("No data: %d matches, %d requests, %v", len(matches), emptyRequests, err)
+		}
+	}
+
+	var respErr *ResponseError
+	if _, _, err := src.Query(QUERY_CATNR, "25544"); !errors.As(err, &respErr) || respErr.Message != "Internal error" {
+		t.Fatalf("Expected ResponseError, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package tle
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Alpha-5 extends the 5-character catalog number field of TLE beyond 99999
+// by replacing the leading digit with a letter. The letters I and O are
+// skipped to avoid confusion with digits, so that A0001 stands for 100001
+// and Z9999 for 339999.
+const ALPHA5_LETTERS string = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// The largest catalog number that can be represented in Alpha-5 format.
+const MAX_ALPHA5 int = (len(ALPHA5_LETTERS)+10)*10000 - 1
+
+// The error returned by EncodeCatNum if the number cannot be represented.
+var errCatNumRange = errors.New("catalog number out of Alpha-5 range")
+
+// Decodes the catalog number in plain or Alpha-5 format.
+func DecodeCatNum(s string) (int, error) {
+	s = strings.TrimSpace(s)
+
+	if len(s) == 5 {
+		if i := strings.IndexByte(ALPHA5_LETTERS, s[0]); i >= 0 {
+			n, err := strconv.Atoi(s[1:])
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid Alpha-5 catalog number: %s", s)
+			}
+			return (i+10)*10000 + n, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid catalog number: %s", s)
+	}
+
+	return n, nil
+}
+
+// Encodes the catalog number into the 5-character TLE field, using Alpha-5
+// format for numbers above 99999.
+func EncodeCatNum(n int) (string, error) {
+	if n < 0 || n > MAX_ALPHA5 {
+		return "", fmt.Errorf("%w: %d", errCatNumRange, n)
+	}
+
+	if n < 100000 {
+		return fmt.Sprintf("%05d", n), nil
+	}
+
+	return fmt.Sprintf("%c%04d", ALPHA5_LETTERS[n/10000-10], n%10000), nil
+}
@@ -0,0 +1,44 @@
+package tle
+
+import "testing"
+
+// Tests DecodeCatNum and EncodeCatNum functions. Encoding of the decoded
+// number must restore the original field. Invalid fields and numbers
+// out of range must be rejected.
+func TestCatNum(t *testing.T) {
+	cases := map[string]int{
+		"00005": 5,
+		"25544": 25544,
+		"99999": 99999,
+		"A0000": 100000,
+		"A0001": 100001,
+		"H9999": 179999,
+		"J0000": 180000,
+		"P1234": 231234,
+		"Z9999": 339999,
+	}
+
+	for k, v := range cases {
+		n, err := DecodeCatNum(k)
+		if err != nil || n != v {
+			t.Fatalf("Decode %s: %d, %v", k, n, err)
+		}
+
+		s, err := EncodeCatNum(v)
+		if err != nil || s != k {
+			t.Fatalf("Encode %d: %s, %v", v, s, err)
+		}
+	}
+
+	for _, s := range []string{"", "I0001", "O0001", "A001", "AB001", "-0001"} {
+		if _, err := DecodeCatNum(s); err == nil {
+			t.Fatalf("%q: error expected", s)
+		}
+	}
+
+	for _, n := range []int{-1, MAX_ALPHA5 + 1} {
+		if _, err := EncodeCatNum(n); err == nil {
+			t.Fatalf("%d: error expected", n)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The length of the TLE Title Line
+const TITLE_LEN int = 24
+
+// Match struct contains a TLE set split into separate lines of text.
+type Match struct {
+	Title, Line1, Line2 string
+
+	// Elements parsed from OMM. If not nil, Line1 and Line2 are empty.
+	OMM *TLE
+}
+
+// Returns the sattellite's catalog number and classification.
+// Alpha-5 catalog numbers are decoded.
+func (m *Match) GetCatNum() string {
+	if m.OMM != nil {
+		return m.OMM.L1.CatNum
+	}
+
+	if len(m.Line1) < 8 {
+		return strings.TrimSpace(m.Line1[2:])
+	}
+
+	if n, err := DecodeCatNum(m.Line1[2:7]); err == nil {
+		return fmt.Sprintf("%05d%s", n, m.Line1[7:8])
+	}
+	return m.Line1[2:8]
+}
+
+// Parses the two-line and three-line sets with lines separated by LF
+// or CRLF. The sets are found by the line numbers at the start of Lines 1
+// and 2. A non-blank line preceding Line 1 that does not belong to
+// the previous set is the title, of any length. The "0 " prefix of the title,
+// used in the 3LE format, is removed. Sets without title are titled with
+// their catalog number. Lines that do not belong to any set are skipped.
+func Parse(stream []byte) []*Match {
+	lines := strings.Split(string(stream), "\n")
+
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+
+	var matches []*Match
+
+	// Index of the first line that does not belong to the previous set
+	free := 0
+
+	for i := 0; i+1 < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "1 ") || !strings.HasPrefix(lines[i+1], "2 ") {
+			continue
+		}
+
+		m := Match{
+			Line1: strings.TrimRight(lines[i], " \t"),
+			Line2: strings.TrimRight(lines[i+1], " \t"),
+		}
+
+		if i > free && len(strings.TrimSpace(lines[i-1])) > 0 {
+			m.Title = strings.TrimPrefix(lines[i-1], "0 ")
+		} else {
+			m.Title = strings.TrimSpace(m.Line1[2:min(len(m.Line1), 7)])
+		}
+
+		matches = append(matches, &m)
+
+		i++
+		free = i + 1
+	}
+
+	return matches
+}
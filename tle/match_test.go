@@ -0,0 +1,43 @@
+package tle
+
+import "testing"
+
+// Tests Parse function. Two-line and three-line sets must be found
+// regardless of the line endings and the length of the title, the 3LE
+// title prefix must be removed, and the lines that do not belong to any
+// set must be skipped.
+func TestParse(t *testing.T) {
+	const (
+		l1 = issLine1
+		l2 = issLine2
+		m1 = "1 35932U 09051B   22013.55765441  .00000268  00000+0  71136-4 0  9999"
+		m2 = "2 35932  98.5837 225.0161 0007892 155.7494 204.4076 14.56655971653547"
+	)
+
+	cases := map[string][]string{
+		"ISS (ZARYA)             \r\n" + l1 + "\r\n" + l2 + "\r\nSWISSCUBE               \r\n" + m1 + "\r\n" + m2 + "\r\n": {"ISS (ZARYA)             ", "SWISSCUBE               "},
+		"ISS (ZARYA)\n" + l1 + "\n" + l2 + "\nSWISSCUBE\n" + m1 + "\n" + m2:                                                {"ISS (ZARYA)", "SWISSCUBE"},
+		l1 + "\n" + l2 + "\n" + m1 + "\n" + m2 + "\n":                                                                      {"25544", "35932"},
+		"# archived sets\n\n" + l1 + "   \r\n" + l2 + "\r\n\r\nSWISSCUBE\r\n" + m1 + "\r\n" + m2 + "\r\n":                  {"25544", "SWISSCUBE"},
+		"0 ISS (ZARYA)\r\n" + l1 + "\r\n" + l2 + "\r\n0 SWISSCUBE EXTENDED MISSION TITLE\r\n" + m1 + "\r\n" + m2:           {"ISS (ZARYA)", "SWISSCUBE EXTENDED MISSION TITLE"},
+		"ISS (ZARYA)\n" + l1 + "\n": {},
+	}
+
+	for stream, titles := range cases {
+		matches := Parse([]byte(stream))
+
+		if len(matches) != len(titles) {
+			t.Fatalf("%q: %d matches, expected %d", stream, len(matches), len(titles))
+		}
+
+		for i, m := range matches {
+			if m.Title != titles[i] {
+				t.Errorf("%q: title %q, expected %q", stream, m.Title, titles[i])
+			}
+
+			if len(m.Line1) != LINE_LEN || len(m.Line2) != LINE_LEN {
+				t.Errorf("%q: improper line lengths: %d, %d", stream, len(m.Line1), len(m.Line2))
+			}
+		}
+	}
+}
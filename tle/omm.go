@@ -0,0 +1,292 @@
+// Functions within this file parse the Orbit Mean-Elements Messages (OMM)
+// defined by CCSDS 502.0-B and served by CelesTrak in JSON, XML, CSV and KVN
+// formats. Every format is first decoded into the records of keyword-value
+// pairs, which are then converted into TLE structs. Unlike TLE, OMM is able
+// to represent catalog numbers longer than 5 digits.
+
+package tle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format of the data requested from the API.
+type Format string
+
+const (
+	FORMAT_TLE  Format = "TLE"
+	FORMAT_JSON Format = "JSON"
+	FORMAT_XML  Format = "XML"
+	FORMAT_CSV  Format = "CSV"
+	FORMAT_KVN  Format = "KVN"
+)
+
+// Layout of the OMM epoch, always expressed in UTC.
+const OMM_EPOCH_LAYOUT = "2006-01-02T15:04:05.999999999"
+
+// Single OMM record - a set of keyword-value pairs, e.g. "INCLINATION": "51.6452".
+type ommRecord map[string]string
+
+// Returns the format of the specified name, case insensitive.
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToUpper(name))
+
+	switch f {
+	case FORMAT_TLE, FORMAT_JSON, FORMAT_XML, FORMAT_CSV, FORMAT_KVN:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format: %s", name)
+	}
+}
+
+// Parses the OMM response in the specified format and returns the results
+// as a list of pointers to Match structs with the parsed elements attached.
+func ParseOMM(stream []byte, f Format) ([]*Match, error) {
+	var (
+		records []ommRecord
+		err     error
+	)
+
+	switch f {
+	case FORMAT_JSON:
+		records, err = decodeJSON(stream)
+	case FORMAT_XML:
+		records, err = decodeXML(stream)
+	case FORMAT_CSV:
+		records, err = decodeCSV(stream)
+	case FORMAT_KVN:
+		records, err = decodeKVN(stream)
+	default:
+		err = fmt.Errorf("not an OMM format: %s", f)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*Match, 0, len(records))
+
+	for _, rec := range records {
+		tle, err := rec.toTLE()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rec["OBJECT_NAME"], err)
+		}
+		matches = append(matches, tle.Match)
+	}
+
+	return matches, nil
+}
+
+// Converts the record into the TLE struct. The returned TLE is attached
+// to its Match, which holds no raw lines.
+func (rec ommRecord) toTLE() (*TLE, error) {
+	var (
+		tle TLE
+		err error
+	)
+
+	tle.Match = &Match{Title: rec["OBJECT_NAME"], OMM: &tle}
+
+	num := func(key string) float64 {
+		if err != nil {
+			return 0
+		}
+
+		var f float64
+
+		f, err = strconv.ParseFloat(strings.TrimSpace(rec[key]), 64)
+		if err != nil {
+			err = fmt.Errorf("invalid %s: %q", key, rec[key])
+		}
+		return f
+	}
+
+	// ---------------- Line 1 ---------------- //
+
+	tle.L1.Number = 1
+	tle.L1.CatNum = strings.TrimSpace(rec["NORAD_CAT_ID"])
+	tle.L1.Class = ExpandClass(rec["CLASSIFICATION_TYPE"])
+
+	// OBJECT_ID is the international designator in the form "1998-067A"
+	if id := rec["OBJECT_ID"]; len(id) > 8 && id[4] == '-' {
+		tle.L1.IntlDesig.LaunchYear, _ = strconv.Atoi(id[:4])
+		tle.L1.IntlDesig.LaunchNum, _ = strconv.Atoi(id[5:8])
+		tle.L1.IntlDesig.LaunchComp = id[8:]
+	}
+
+	epoch, epochErr := time.Parse(OMM_EPOCH_LAYOUT, strings.TrimSpace(rec["EPOCH"]))
+	if epochErr != nil {
+		return nil, fmt.Errorf("invalid EPOCH: %q", rec["EPOCH"])
+	}
+
+	tle.L1.Epoch.Year, tle.L1.Epoch.Day = TimeToEpoch(epoch)
+
+	tle.L1.MnMDrvs.First = num("MEAN_MOTION_DOT")
+	tle.L1.MnMDrvs.Second = num("MEAN_MOTION_DDOT")
+	tle.L1.BSTAR = num("BSTAR")
+
+	tle.L1.EphemerisType = int(num("EPHEMERIS_TYPE"))
+	tle.L1.ElSetNum = int(num("ELEMENT_SET_NO"))
+
+	// ---------------- Line 2 ---------------- //
+
+	tle.L2.Number = 2
+	tle.L2.CatNum = tle.L1.CatNum
+
+	tle.L2.Inc = num("INCLINATION")
+	tle.L2.LAN = num("RA_OF_ASC_NODE")
+	tle.L2.Ecc = num("ECCENTRICITY")
+	tle.L2.AgP = num("ARG_OF_PERICENTER")
+	tle.L2.MnA = num("MEAN_ANOMALY")
+	tle.L2.MnM = num("MEAN_MOTION")
+
+	tle.L2.RevN = int(num("REV_AT_EPOCH"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tle, nil
+}
+
+// Decodes the JSON array of OMM objects.
+func decodeJSON(stream []byte) ([]ommRecord, error) {
+	var objects []map[string]json.RawMessage
+
+	if err := json.Unmarshal(stream, &objects); err != nil {
+		return nil, err
+	}
+
+	records := make([]ommRecord, len(objects))
+
+	for i, obj := range objects {
+		records[i] = make(ommRecord, len(obj))
+
+		for k, raw := range obj {
+			// Strings are unquoted, numbers are kept in their original notation
+			var s string
+			if json.Unmarshal(raw, &s) != nil {
+				s = string(raw)
+			}
+			records[i][k] = s
+		}
+	}
+
+	return records, nil
+}
+
+// Decodes the CCSDS NDM/XML document. Every 'omm' element becomes a record
+// containing the text of all its leaf elements, regardless of nesting.
+func decodeXML(stream []byte) ([]ommRecord, error) {
+	var (
+		records []ommRecord
+		cur     ommRecord
+		text    strings.Builder
+	)
+
+	dec := xml.NewDecoder(bytes.NewReader(stream))
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local == "omm" {
+				cur = make(ommRecord)
+			}
+			text.Reset()
+		case xml.CharData:
+			text.Write(el)
+		case xml.EndElement:
+			if el.Name.Local == "omm" && cur != nil {
+				records = append(records, cur)
+				cur = nil
+			} else if cur != nil {
+				if value := strings.TrimSpace(text.String()); len(value) > 0 {
+					cur[el.Name.Local] = value
+				}
+			}
+			text.Reset()
+		}
+	}
+
+	return records, nil
+}
+
+// Decodes the CSV table, the first row of which contains the keywords.
+func decodeCSV(stream []byte) ([]ommRecord, error) {
+	rows, err := csv.NewReader(bytes.NewReader(stream)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	records := make([]ommRecord, 0, len(rows)-1)
+
+	for _, row := range rows[1:] {
+		rec := make(ommRecord, len(header))
+		for i := range header {
+			rec[strings.TrimSpace(header[i])] = row[i]
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// Decodes the keyword = value notation. Every message begins with
+// CCSDS_OMM_VERS keyword. Comments and units in brackets are omitted.
+func decodeKVN(stream []byte) ([]ommRecord, error) {
+	var (
+		records []ommRecord
+		cur     ommRecord
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(stream))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "COMMENT") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid KVN line: %q", line)
+		}
+
+		key = strings.TrimSpace(key)
+		value, _, _ = strings.Cut(value, "[")
+		value = strings.TrimSpace(value)
+
+		if key == "CCSDS_OMM_VERS" {
+			cur = make(ommRecord)
+			records = append(records, cur)
+		} else if cur == nil {
+			return nil, fmt.Errorf("KVN message must begin with CCSDS_OMM_VERS, found %s", key)
+		}
+
+		cur[key] = value
+	}
+
+	return records, scanner.Err()
+}
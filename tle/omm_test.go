@@ -0,0 +1,136 @@
+package tle
+
+import (
+	"math"
+	"testing"
+)
+
+// ISS elements in OMM formats, equivalent to the TLE set used in the other
+// tests. The second object has a catalog number that TLE cannot represent.
+var ommSamples = map[Format]string{
+	FORMAT_JSON: `[{"OBJECT_NAME":"ISS (ZARYA)","OBJECT_ID":"1998-067A","EPOCH":"2022-01-14T04:49:07.412736","MEAN_MOTION":15.49476744,"ECCENTRICITY":0.0006828,"INCLINATION":51.6452,"RA_OF_ASC_NODE":19.1428,"ARG_OF_PERICENTER":17.5887,"MEAN_ANOMALY":10.3753,"EPHEMERIS_TYPE":0,"CLASSIFICATION_TYPE":"U","NORAD_CAT_ID":25544,"ELEMENT_SET_NO":999,"REV_AT_EPOCH":32130,"BSTAR":-2.0061e-5,"MEAN_MOTION_DOT":-1.581e-5,"MEAN_MOTION_DDOT":0},
+{"OBJECT_NAME":"TEST OBJECT","OBJECT_ID":"2022-001B","EPOCH":"2022-01-14T00:00:00","MEAN_MOTION":14.5,"ECCENTRICITY":0.001,"INCLINATION":97.5,"RA_OF_ASC_NODE":10,"ARG_OF_PERICENTER":20,"MEAN_ANOMALY":30,"EPHEMERIS_TYPE":0,"CLASSIFICATION_TYPE":"U","NORAD_CAT_ID":270000123,"ELEMENT_SET_NO":1,"REV_AT_EPOCH":1,"BSTAR":0,"MEAN_MOTION_DOT":0,"MEAN_MOTION_DDOT":0}]`,
+
+	FORMAT_XML: `<?xml version="1.0" encoding="UTF-8"?>
+<ndm xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<omm id="CCSDS_OMM_VERS" version="2.0">
+<header><CREATION_DATE/><ORIGINATOR/></header>
+<body><segment>
+<metadata><OBJECT_NAME>ISS (ZARYA)</OBJECT_NAME><OBJECT_ID>1998-067A</OBJECT_ID><CENTER_NAME>EARTH</CENTER_NAME><REF_FRAME>TEME</REF_FRAME><TIME_SYSTEM>UTC</TIME_SYSTEM><MEAN_ELEMENT_THEORY>SGP4</MEAN_ELEMENT_THEORY></metadata>
+<data>
+<meanElements><EPOCH>2022-01-14T04:49:07.412736</EPOCH><MEAN_MOTION>15.49476744</MEAN_MOTION><ECCENTRICITY>.0006828</ECCENTRICITY><INCLINATION>51.6452</INCLINATION><RA_OF_ASC_NODE>19.1428</RA_OF_ASC_NODE><ARG_OF_PERICENTER>17.5887</ARG_OF_PERICENTER><MEAN_ANOMALY>10.3753</MEAN_ANOMALY></meanElements>
+<tleParameters><EPHEMERIS_TYPE>0</EPHEMERIS_TYPE><CLASSIFICATION_TYPE>U</CLASSIFICATION_TYPE><NORAD_CAT_ID>25544</NORAD_CAT_ID><ELEMENT_SET_NO>999</ELEMENT_SET_NO><REV_AT_EPOCH>32130</REV_AT_EPOCH><BSTAR>-.20061E-4</BSTAR><MEAN_MOTION_DOT>-.1581E-4</MEAN_MOTION_DOT><MEAN_MOTION_DDOT>0</MEAN_MOTION_DDOT></tleParameters>
+</data>
+</segment></body>
+</omm>
+<omm id="CCSDS_OMM_VERS" version="2.0">
+<body><segment>
+<metadata><OBJECT_NAME>TEST OBJECT</OBJECT_NAME><OBJECT_ID>2022-001B</OBJECT_ID></metadata>
+<data>
+<meanElements><EPOCH>2022-01-14T00:00:00</EPOCH><MEAN_MOTION>14.5</MEAN_MOTION><ECCENTRICITY>.001</ECCENTRICITY><INCLINATION>97.5</INCLINATION><RA_OF_ASC_NODE>10</RA_OF_ASC_NODE><ARG_OF_PERICENTER>20</ARG_OF_PERICENTER><MEAN_ANOMALY>30</MEAN_ANOMALY></meanElements>
+<tleParameters><EPHEMERIS_TYPE>0</EPHEMERIS_TYPE><CLASSIFICATION_TYPE>U</CLASSIFICATION_TYPE><NORAD_CAT_ID>270000123</NORAD_CAT_ID><ELEMENT_SET_NO>1</ELEMENT_SET_NO><REV_AT_EPOCH>1</REV_AT_EPOCH><BSTAR>0</BSTAR><MEAN_MOTION_DOT>0</MEAN_MOTION_DOT><MEAN_MOTION_DDOT>0</MEAN_MOTION_DDOT></tleParameters>
+</data>
+</segment></body>
+</omm>
+</ndm>
+`,
+
+	FORMAT_CSV: "OBJECT_NAME,OBJECT_ID,EPOCH,MEAN_MOTION,ECCENTRICITY,INCLINATION,RA_OF_ASC_NODE,ARG_OF_PERICENTER,MEAN_ANOMALY,EPHEMERIS_TYPE,CLASSIFICATION_TYPE,NORAD_CAT_ID,ELEMENT_SET_NO,REV_AT_EPOCH,BSTAR,MEAN_MOTION_DOT,MEAN_MOTION_DDOT\r\n" +
+		"ISS (ZARYA),1998-067A,2022-01-14T04:49:07.412736,15.49476744,.0006828,51.6452,19.1428,17.5887,10.3753,0,U,25544,999,32130,-.20061E-4,-.1581E-4,0\r\n" +
+		"TEST OBJECT,2022-001B,2022-01-14T00:00:00,14.5,.001,97.5,10,20,30,0,U,270000123,1,1,0,0,0\r\n",
+
+	FORMAT_KVN: `CCSDS_OMM_VERS = 2.0
+COMMENT GENERATED VIA SPACE-TRACK.ORG API
+OBJECT_NAME = ISS (ZARYA)
+OBJECT_ID = 1998-067A
+CENTER_NAME = EARTH
+REF_FRAME = TEME
+TIME_SYSTEM = UTC
+MEAN_ELEMENT_THEORY = SGP4
+EPOCH = 2022-01-14T04:49:07.412736
+MEAN_MOTION = 15.49476744 [rev/day]
+ECCENTRICITY = .0006828
+INCLINATION = 51.6452 [deg]
+RA_OF_ASC_NODE = 19.1428 [deg]
+ARG_OF_PERICENTER = 17.5887 [deg]
+MEAN_ANOMALY = 10.3753 [deg]
+EPHEMERIS_TYPE = 0
+CLASSIFICATION_TYPE = U
+NORAD_CAT_ID = 25544
+ELEMENT_SET_NO = 999
+REV_AT_EPOCH = 32130
+BSTAR = -.20061E-4
+MEAN_MOTION_DOT = -.1581E-4
+MEAN_MOTION_DDOT = 0
+CCSDS_OMM_VERS = 2.0
+OBJECT_NAME = TEST OBJECT
+OBJECT_ID = 2022-001B
+EPOCH = 2022-01-14T00:00:00
+MEAN_MOTION = 14.5
+ECCENTRICITY = .001
+INCLINATION = 97.5
+RA_OF_ASC_NODE = 10
+ARG_OF_PERICENTER = 20
+MEAN_ANOMALY = 30
+EPHEMERIS_TYPE = 0
+CLASSIFICATION_TYPE = U
+NORAD_CAT_ID = 270000123
+ELEMENT_SET_NO = 1
+REV_AT_EPOCH = 1
+BSTAR = 0
+MEAN_MOTION_DOT = 0
+MEAN_MOTION_DDOT = 0
+`,
+}
+
+// Tests ParseOMM function. Every format must produce
+// the same values as the equivalent TLE set, and the long catalog number
+// must be preserved.
+func TestParseOMM(t *testing.T) {
+	ref, err := ParseMatch(testISS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for f, sample := range ommSamples {
+		matches, err := ParseOMM([]byte(sample), f)
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+
+		if len(matches) != 2 {
+			t.Fatalf("%s: expected 2 matches, got %d", f, len(matches))
+		}
+
+		if matches[0].Title != ref.Match.Title {
+			t.Fatalf("%s: unexpected title %q", f, matches[0].Title)
+		}
+
+		tle, _ := ParseMatch(matches[0])
+
+		pass :=
+			tle.L1.CatNum == ref.L1.CatNum &&
+				tle.L1.Class == ref.L1.Class &&
+				tle.L1.IntlDesig == ref.L1.IntlDesig &&
+				tle.L1.Epoch.Year == ref.L1.Epoch.Year &&
+				math.Abs(tle.L1.Epoch.Day-ref.L1.Epoch.Day) < 1e-9 &&
+				tle.L1.MnMDrvs == ref.L1.MnMDrvs &&
+				tle.L1.BSTAR == ref.L1.BSTAR &&
+				tle.L1.ElSetNum == ref.L1.ElSetNum &&
+				tle.L2.Inc == ref.L2.Inc &&
+				tle.L2.LAN == ref.L2.LAN &&
+				tle.L2.Ecc == ref.L2.Ecc &&
+				tle.L2.AgP == ref.L2.AgP &&
+				tle.L2.MnA == ref.L2.MnA &&
+				tle.L2.MnM == ref.L2.MnM &&
+				tle.L2.RevN == ref.L2.RevN
+
+		if !pass {
+			t.Fatalf("%s: values differ from TLE:\n%+v\n%+v", f, tle, ref)
+		}
+
+		if cat := matches[1].GetCatNum(); cat != "270000123" {
+			t.Fatalf("%s: unexpected catalog number %s", f, cat)
+		}
+	}
+}
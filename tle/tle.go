@@ -0,0 +1,302 @@
+// Package tle parses, validates and encodes Two-Line Element sets
+// and decodes the CCSDS Orbit Mean-Elements Messages.
+package tle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The length of TLE Lines 1 and 2
+const LINE_LEN int = 69
+
+// TLEError describes the part of the TLE set that failed validation.
+type TLEError struct {
+	// Line number: 1 or 2
+	Line int
+
+	// Range of columns, numbered from 1 as in the format specification
+	First, Last int
+
+	// Name of the field
+	Field string
+
+	// Content of the field
+	Value string
+
+	// Description of the failure
+	Reason string
+}
+
+func (e *TLEError) Error() string {
+	return fmt.Sprintf("line %d, columns %d-%d (%s): %s", e.Line, e.First, e.Last, e.Field, e.Reason)
+}
+
+// TLE struct holds values extracted from the original two-line string
+// set contained in Match.
+type TLE struct {
+	// Original Match struct that underwent parsing into TLE struct
+	Match *Match
+
+	// Fields that could not be parsed. Their values are set to zero.
+	Warnings []*TLEError
+
+	// Line1
+	L1 struct {
+		// Line number
+		Number int
+
+		// Satellite catalog number, Alpha-5 decoded
+		CatNum string
+
+		// Classification: Classified, Secret, Unclassified
+		Class string
+
+		// International Designator
+		IntlDesig struct {
+
+			// Launch year
+			LaunchYear int
+
+			// Number of launch made on that year
+			LaunchNum int
+
+			// Launch component in order
+			LaunchComp string
+		}
+
+		Epoch struct {
+			Year int
+			Day  float64
+		}
+
+		// Mean Motion Derivatives
+		MnMDrvs struct {
+			First,
+			Second float64
+		}
+
+		BSTAR float64
+
+		EphemerisType int
+
+		// Element set number (number of TLE sets generated for this object)
+		ElSetNum int
+
+		Checksum int
+	}
+
+	// Line 2
+	L2 struct {
+		// Line number
+		Number int
+
+		// Satellite catalog number, Alpha-5 decoded
+		CatNum string
+
+		// Inclination
+		Inc float64
+
+		// Longitude of Ascending Node
+		LAN float64
+
+		// Eccentricity
+		Ecc float64
+
+		// Argument of Periapsis
+		AgP float64
+
+		// Mean Anomaly
+		MnA float64
+
+		// Mean Motion
+		MnM float64
+
+		// Number of revolutions
+		RevN int
+
+		Checksum int
+	}
+}
+
+// Converts the Match struct into the TLE struct, splitting all the values.
+// If the Match comes from OMM, the already parsed TLE is returned.
+// Returns *TLEError if the set is damaged.
+func ParseMatch(m *Match) (*TLE, error) {
+	if m.OMM != nil {
+		return m.OMM, nil
+	}
+
+	if err := Validate(m.Line1, m.Line2); err != nil {
+		return nil, err
+	}
+
+	var tle TLE
+
+	// --------------- Original --------------- //
+
+	tle.Match = m
+
+	// ---------------- Line 1 ---------------- //
+
+	p := fieldParser{tle: &tle, n: 1, line: m.Line1}
+
+	tle.L1.Number = p.int(1, 1, "line number")
+	tle.L1.CatNum = p.catNum(3, 7)
+	tle.L1.Class = ExpandClass(p.str(8, 8))
+
+	// International designator is blank for some objects
+	if len(p.str(10, 17)) > 0 {
+		tle.L1.IntlDesig.LaunchYear = NormalizeYear(p.int(10, 11, "launch year"))
+		tle.L1.IntlDesig.LaunchNum = p.int(12, 14, "launch number")
+		tle.L1.IntlDesig.LaunchComp = p.str(15, 17)
+	}
+
+	tle.L1.Epoch.Year = NormalizeYear(p.int(19, 20, "epoch year"))
+	tle.L1.Epoch.Day = p.float(21, 32, "epoch day", false)
+
+	tle.L1.MnMDrvs.First = p.float(34, 43, "1st derivative", false)
+	tle.L1.MnMDrvs.Second = p.float(45, 52, "2nd derivative", true)
+	tle.L1.BSTAR = p.float(54, 61, "BSTAR", true)
+
+	tle.L1.EphemerisType = p.int(63, 63, "ephemeris type")
+	tle.L1.ElSetNum = p.int(65, 68, "element set number")
+
+	tle.L1.Checksum = p.int(69, 69, "checksum")
+
+	// ---------------- Line 2 ---------------- //
+
+	p = fieldParser{tle: &tle, n: 2, line: m.Line2}
+
+	tle.L2.Number = p.int(1, 1, "line number")
+	tle.L2.CatNum = p.catNum(3, 7)
+
+	tle.L2.Inc = p.float(9, 16, "inclination", false)
+	tle.L2.LAN = p.float(18, 25, "LAN", false)
+	tle.L2.Ecc = p.float(27, 33, "eccentricity", true)
+	tle.L2.AgP = p.float(35, 42, "arg of periapsis", false)
+	tle.L2.MnA = p.float(44, 51, "mean anomaly", false)
+	tle.L2.MnM = p.float(53, 63, "mean motion", false)
+
+	tle.L2.RevN = p.int(64, 68, "revolution number")
+
+	tle.L2.Checksum = p.int(69, 69, "checksum")
+
+	return &tle, nil
+}
+
+// Helper struct for ParseMatch. Extracts the fields of a single TLE line
+// and records those that cannot be parsed as the warnings of the TLE.
+type fieldParser struct {
+	tle  *TLE
+	n    int
+	line string
+}
+
+// Returns the trimmed field spanning the columns first to last,
+// numbered from 1 as in the format specification.
+func (p *fieldParser) str(first, last int) string {
+	return strings.TrimSpace(p.line[first-1 : last])
+}
+
+// Parses the field as a float. If normalize is true, the decimal point
+// and the exponent of ten notation are assumed. Returns 0 and records
+// the warning if the field cannot be parsed.
+func (p *fieldParser) float(first, last int, field string, normalize bool) float64 {
+	s := p.str(first, last)
+	if normalize {
+		s = NormalizeFloat(s)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		p.warn(first, last, field)
+	}
+
+	return f
+}
+
+// Parses the field as an integer. Returns 0 and records the warning
+// if the field cannot be parsed.
+func (p *fieldParser) int(first, last int, field string) int {
+	i, err := strconv.Atoi(p.str(first, last))
+	if err != nil {
+		p.warn(first, last, field)
+	}
+
+	return i
+}
+
+// Parses the catalog number in plain or Alpha-5 format and returns it
+// as a decimal number, padded with zeros to 5 digits. Returns the field
+// unchanged and records the warning if it cannot be decoded.
+func (p *fieldParser) catNum(first, last int) string {
+	n, err := DecodeCatNum(p.str(first, last))
+	if err != nil {
+		p.warn(first, last, "catalog number")
+		return p.str(first, last)
+	}
+
+	return fmt.Sprintf("%05d", n)
+}
+
+// Records the warning about the field that cannot be parsed.
+func (p *fieldParser) warn(first, last int, field string) {
+	p.tle.Warnings = append(p.tle.Warnings, &TLEError{
+		Line:   p.n,
+		First:  first,
+		Last:   last,
+		Field:  field,
+		Value:  p.line[first-1 : last],
+		Reason: fmt.Sprintf("cannot parse %q", p.line[first-1:last]),
+	})
+}
+
+// Checks the length, line numbers and checksums of TLE lines, as well
+// as the consistency of catalog numbers. Returns *TLEError describing
+// the first problem found.
+func Validate(line1, line2 string) error {
+	for i, line := range []string{line1, line2} {
+		n := i + 1
+
+		if len(line) != LINE_LEN {
+			return &TLEError{n, 1, max(len(line), 1), "line", line, fmt.Sprintf("expected %d characters, found %d", LINE_LEN, len(line))}
+		}
+
+		if line[:1] != fmt.Sprint(n) {
+			return &TLEError{n, 1, 1, "line number", line[:1], fmt.Sprintf("expected %d, found %q", n, line[:1])}
+		}
+
+		if sum := Checksum(line); line[68:69] != fmt.Sprint(sum) {
+			return &TLEError{n, 69, 69, "checksum", line[68:69], fmt.Sprintf("expected %d, found %q", sum, line[68:69])}
+		}
+	}
+
+	if line1[2:7] != line2[2:7] {
+		return &TLEError{2, 3, 7, "catalog number", line2[2:7], fmt.Sprintf("%q does not match %q on line 1", line2[2:7], line1[2:7])}
+	}
+
+	return nil
+}
+
+// Computes the modulo 10 checksum of the TLE line: digits are added,
+// minus signs count as 1 and all other characters are ignored.
+// The last column, containing the checksum itself, is omitted.
+func Checksum(line string) int {
+	if len(line) > LINE_LEN-1 {
+		line = line[:LINE_LEN-1]
+	}
+
+	var sum int
+
+	for _, r := range line {
+		if r >= '0' && r <= '9' {
+			sum += int(r - '0')
+		} else if r == '-' {
+			sum++
+		}
+	}
+
+	return sum % 10
+}
@@ -0,0 +1,193 @@
+package tle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Lines of the ISS set shared by the tests.
+const (
+	issLine1 = "1 25544U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  9991"
+	issLine2 = "2 25544  51.6452  19.1428 0006828  17.5887  10.3753 15.49476744321309"
+)
+
+// Returns the Match of the ISS set shared by the tests.
+func testISS() *Match {
+	return &Match{Title: "ISS (ZARYA)", Line1: issLine1, Line2: issLine2}
+}
+
+// Tests ParseLines function, feeding it with data. Output must be equal to
+// the specified values.
+func TestParseLines(t *testing.T) {
+	// Sample Match struct with TLE set downloaded from the API
+	m := Match{
+		Title: "ISS (ZARYA)",
+		Line1: "1 25544U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  9991",
+		Line2: "2 25544  51.6452  19.1428 0006828  17.5887  10.3753 15.49476744321309",
+	}
+
+	tle, err := ParseMatch(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pass1 :=
+		tle.L1.Number == 1 &&
+			tle.L1.CatNum == "25544" &&
+			tle.L1.Class == "Unclassified" &&
+			tle.L1.IntlDesig.LaunchYear == 1998 &&
+			tle.L1.IntlDesig.LaunchNum == 67 &&
+			tle.L1.IntlDesig.LaunchComp == "A" &&
+			tle.L1.Epoch.Year == 2022 &&
+			tle.L1.Epoch.Day == 14.20078024 &&
+			tle.L1.MnMDrvs.First == -0.00001581 &&
+			tle.L1.MnMDrvs.Second == 0 &&
+			tle.L1.BSTAR == -0.20061e-4 &&
+			tle.L1.EphemerisType == 0 &&
+			tle.L1.ElSetNum == 999 &&
+			tle.L1.Checksum == 1
+
+	pass2 :=
+		tle.L2.Number == 2 &&
+			tle.L2.CatNum == "25544" &&
+			tle.L2.Inc == 51.6452 &&
+			tle.L2.LAN == 19.1428 &&
+			tle.L2.Ecc == 0.0006828 &&
+			tle.L2.AgP == 17.5887 &&
+			tle.L2.MnA == 10.3753 &&
+			tle.L2.MnM == 15.49476744 &&
+			tle.L2.RevN == 32130 &&
+			tle.L2.Checksum == 9
+
+	var fatalMsg string
+
+	if !pass1 {
+		fatalMsg = "Parsing failed at Line 1"
+	}
+
+	if !pass2 {
+		if len(fatalMsg) > 0 {
+			fatalMsg += " and Line 2"
+		} else {
+			fatalMsg = "Parsing failed at Line 2"
+		}
+	}
+
+	if !(pass1 && pass2) {
+		t.Fatal(fatalMsg)
+	}
+}
+
+// Tests ParseMatch function with damaged sets. Every set must be rejected
+// with *TLEError pointing at the damaged line, columns and field.
+func TestParseMatchErrors(t *testing.T) {
+	const (
+		line1 = issLine1
+		line2 = issLine2
+	)
+
+	cases := []struct {
+		Line1, Line2 string
+		Expected     TLEError
+	}{
+		{line1[:68], line2, TLEError{Line: 1, First: 1, Last: 68, Field: "line"}},
+		{line1, line2 + " ", TLEError{Line: 2, First: 1, Last: 70, Field: "line"}},
+		{line2, line2, TLEError{Line: 1, First: 1, Last: 1, Field: "line number"}},
+		{line1[:68] + "2", line2, TLEError{Line: 1, First: 69, Last: 69, Field: "checksum"}},
+		{line1, strings.Replace(line2, "51.6452", "51.6453", 1), TLEError{Line: 2, First: 69, Last: 69, Field: "checksum"}},
+		{line1, "2 25545" + line2[7:68] + "0", TLEError{Line: 2, First: 3, Last: 7, Field: "catalog number"}},
+	}
+
+	for i, c := range cases {
+		_, err := ParseMatch(&Match{Title: "ISS (ZARYA)", Line1: c.Line1, Line2: c.Line2})
+
+		var tleErr *TLEError
+
+		if !errors.As(err, &tleErr) {
+			t.Fatalf("Case %d: expected *TLEError, got %v", i, err)
+		}
+
+		if tleErr.Line != c.Expected.Line || tleErr.First != c.Expected.First || tleErr.Last != c.Expected.Last || tleErr.Field != c.Expected.Field {
+			t.Fatalf("Case %d: unexpected error: %v", i, err)
+		}
+	}
+}
+
+// Tests ParseMatch function with fields that cannot be parsed. The set must
+// be accepted, the fields must be set to zero and reported as warnings
+// with their exact content.
+func TestParseMatchWarnings(t *testing.T) {
+	m := Match{
+		Title: "ISS (ZARYA)",
+		Line1: strings.Replace(issLine1, "-20061-4", "-2OO61-4", 1),
+		Line2: issLine2,
+	}
+
+	tle, err := ParseMatch(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tle.Warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(tle.Warnings))
+	}
+
+	w := tle.Warnings[0]
+
+	if w.Line != 1 || w.First != 54 || w.Last != 61 || w.Field != "BSTAR" || w.Value != "-2OO61-4" || tle.L1.BSTAR != 0 {
+		t.Fatalf("Unexpected warning: %v, BSTAR = %g", w, tle.L1.BSTAR)
+	}
+}
+
+// Tests whether ParseMatch reads the whole width of the fields: the sign
+// of the second derivative, the hundreds of the inclination and all
+// the characters of the launch piece.
+func TestParseMatchFieldWidth(t *testing.T) {
+	withChecksum := func(line string) string {
+		return line + fmt.Sprint(Checksum(line))
+	}
+
+	m := Match{
+		Title: "TEST",
+		Line1: withChecksum("1 25544U 98067ABC 22014.20078024 -.00001581 -12345-5 -20061-4 0 1999"),
+		Line2: withChecksum("2 25544 101.6452  19.1428 0006828  17.5887  10.3753 15.4947674432130"),
+	}
+
+	tle, err := ParseMatch(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tle.Warnings) > 0 {
+		t.Fatalf("Unexpected warnings: %v", tle.Warnings[0])
+	}
+
+	if tle.L1.MnMDrvs.Second != -0.12345e-5 || tle.L2.Inc != 101.6452 || tle.L1.IntlDesig.LaunchComp != "ABC" || tle.L1.ElSetNum != 1999 {
+		t.Fatalf("Unexpected values: %g, %g, %q, %d", tle.L1.MnMDrvs.Second, tle.L2.Inc, tle.L1.IntlDesig.LaunchComp, tle.L1.ElSetNum)
+	}
+}
+
+// Tests ParseMatch function with Alpha-5 catalog number. The number must
+// be decoded in TLE and in the results list.
+func TestParseMatchAlpha5(t *testing.T) {
+	withChecksum := func(line string) string {
+		return line + fmt.Sprint(Checksum(line))
+	}
+
+	m := Match{
+		Title: "TEST",
+		Line1: withChecksum("1 B1234U 98067A   22014.20078024 -.00001581  00000+0 -20061-4 0  999"),
+		Line2: withChecksum("2 B1234  51.6452  19.1428 0006828  17.5887  10.3753 15.4947674432130"),
+	}
+
+	tle, err := ParseMatch(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tle.L1.CatNum != "111234" || tle.L2.CatNum != "111234" || m.GetCatNum() != "111234U" {
+		t.Fatalf("Unexpected catalog numbers: %s, %s, %s", tle.L1.CatNum, tle.L2.CatNum, m.GetCatNum())
+	}
+}
@@ -0,0 +1,82 @@
+package tle
+
+import (
+	"strings"
+	"time"
+)
+
+// Expands NORAD classification abbreviations.
+func ExpandClass(c string) string {
+	switch c {
+	case "C":
+		return "Classified"
+	case "S":
+		return "Secret"
+	case "U":
+		return "Unclassified"
+	default:
+		return "Unknown"
+	}
+}
+
+// Normalizes floating point numbers contained in TLE, as the decimal point
+// and the exponent of ten notation are often assumed. This function prepares
+// a string for parsing into float.
+func NormalizeFloat(s string) string {
+	// Add decimal point
+	if !strings.Contains(s, ".") {
+		if strings.HasPrefix(s, "-") {
+			s = s[:1] + "." + s[1:]
+		} else {
+			s = "." + s
+		}
+	}
+
+	// Add the exponent of ten notation
+	liMinus := strings.LastIndex(s, "-")
+	liPlus := strings.LastIndex(s, "+")
+
+	var li int
+
+	if liMinus > 0 {
+		li = liMinus
+	} else if liPlus > 0 {
+		li = liPlus
+	} else {
+		return s
+	}
+
+	return s[:li] + "e" + s[li:]
+}
+
+// Since the year in international designator is represented as a two-digit
+// number, this function is needed to make sure a proper century is indicated.
+// 57-99 == 1957-1999
+// 00-56 == 2000-2056
+func NormalizeYear(y int) int {
+	if y > 56 {
+		return 1900 + y
+	}
+	return 2000 + y
+}
+
+// Converts epoch year and fraction of a day extracted from TLE
+// to unix time in seconds.
+func EpochToUnix(epochYear int, epochDay float64) int64 {
+	return time.Date(epochYear, time.January, 0, 0, 0, 0, 0, time.UTC).Unix() + int64(86400*epochDay)
+}
+
+// Converts epoch year and fraction of a day extracted from TLE to time.Time.
+// Unlike EpochToUnix, the fraction of a second is preserved.
+func EpochToTime(epochYear int, epochDay float64) time.Time {
+	start := time.Date(epochYear, time.January, 0, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(epochDay * float64(24*time.Hour)))
+}
+
+// Converts time.Time to epoch year and fraction of a day, as stored in TLE.
+// The inverse of EpochToTime.
+func TimeToEpoch(t time.Time) (epochYear int, epochDay float64) {
+	t = t.UTC()
+	start := time.Date(t.Year(), time.January, 0, 0, 0, 0, 0, time.UTC)
+	return t.Year(), t.Sub(start).Hours() / 24
+}
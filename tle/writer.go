@@ -0,0 +1,186 @@
+package tle
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Returns the three-line set, each line terminated with CRLF,
+// as served by the API.
+func (m *Match) String() string {
+	return fmt.Sprintf("%-*s\r\n%s\r\n%s\r\n", TITLE_LEN, m.Title, m.Line1, m.Line2)
+}
+
+// Encodes the TLE struct into a three-line set, computing the checksums.
+// Returns an error if any of the values cannot be represented
+// in the format, or if the catalog number cannot be decoded.
+func EncodeTLE(tle *TLE) (*Match, error) {
+	var (
+		l1, l2 strings.Builder
+		err    error
+	)
+
+	// Stores the first error, so that all the fields can be written in sequence
+	check := func(s string, e error) string {
+		if err == nil {
+			err = e
+		}
+		return s
+	}
+
+	// Sets created from scratch carry no catalog number and are encoded as 00000
+	var catNum int
+	if len(strings.TrimSpace(tle.L1.CatNum)) > 0 {
+		catNum, err = DecodeCatNum(tle.L1.CatNum)
+	}
+	catField := check(EncodeCatNum(catNum))
+
+	// ---------------- Line 1 ---------------- //
+
+	l1.WriteString("1 ")
+	l1.WriteString(catField)
+	l1.WriteString(shortenClass(tle.L1.Class))
+	l1.WriteString(" ")
+
+	if id := tle.L1.IntlDesig; id.LaunchYear > 0 {
+		fmt.Fprintf(&l1, "%02d%03d%-3s ", id.LaunchYear%100, id.LaunchNum, id.LaunchComp)
+	} else {
+		l1.WriteString("         ")
+	}
+
+	fmt.Fprintf(&l1, "%02d%012.8f ", tle.L1.Epoch.Year%100, tle.L1.Epoch.Day)
+
+	l1.WriteString(check(formatDecimal(tle.L1.MnMDrvs.First, "1st derivative")))
+	l1.WriteString(" ")
+	l1.WriteString(check(formatExponent(tle.L1.MnMDrvs.Second, "2nd derivative")))
+	l1.WriteString(" ")
+	l1.WriteString(check(formatExponent(tle.L1.BSTAR, "BSTAR")))
+
+	fmt.Fprintf(&l1, " %d %4d", tle.L1.EphemerisType%10, tle.L1.ElSetNum%10000)
+
+	// ---------------- Line 2 ---------------- //
+
+	l2.WriteString("2 ")
+	l2.WriteString(catField)
+
+	fmt.Fprintf(&l2, " %8.4f %8.4f ", tle.L2.Inc, roundAngle(tle.L2.LAN))
+
+	l2.WriteString(check(formatEccentricity(tle.L2.Ecc)))
+
+	fmt.Fprintf(
+		&l2, " %8.4f %8.4f %11.8f%5d",
+		roundAngle(tle.L2.AgP), roundAngle(tle.L2.MnA), tle.L2.MnM, tle.L2.RevN%100000,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	line1, line2 := l1.String(), l2.String()
+
+	if len(line1) != LINE_LEN-1 || len(line2) != LINE_LEN-1 {
+		return nil, fmt.Errorf("value out of range: %q, %q", line1, line2)
+	}
+
+	var title string
+	if tle.Match != nil {
+		title = tle.Match.Title
+	}
+
+	return &Match{
+		Title: title,
+		Line1: line1 + fmt.Sprint(Checksum(line1)),
+		Line2: line2 + fmt.Sprint(Checksum(line2)),
+	}, nil
+}
+
+// Formats the number with the leading decimal point and 8 decimal places,
+// e.g. "-.00001581". The number must be lesser than 1 in magnitude.
+func formatDecimal(f float64, field string) (string, error) {
+	s := fmt.Sprintf("%.8f", math.Abs(f))
+	if !strings.HasPrefix(s, "0.") {
+		return "", fmt.Errorf("cannot encode %s: %g", field, f)
+	}
+
+	return signChar(f) + s[1:], nil
+}
+
+// Formats the number in the implied-decimal exponent notation - the inverse
+// of NormalizeFloat, e.g. -0.20061e-4 becomes "-20061-4". The mantissa
+// is rounded to 5 digits.
+func formatExponent(f float64, field string) (string, error) {
+	a := math.Abs(f)
+
+	if a == 0 {
+		return " 00000+0", nil
+	}
+
+	exp := int(math.Floor(math.Log10(a))) + 1
+	mantissa := int(math.Round(a / math.Pow(10, float64(exp)) * 1e5))
+
+	// Rounding may carry over to the next order of magnitude
+	if mantissa == 100000 {
+		mantissa = 10000
+		exp++
+	}
+
+	// Numbers too small to be represented are written as zero
+	if exp < -9 {
+		return " 00000+0", nil
+	}
+
+	if exp > 9 {
+		return "", fmt.Errorf("cannot encode %s: %g", field, f)
+	}
+
+	return fmt.Sprintf("%s%05d%+d", signChar(f), mantissa, exp), nil
+}
+
+// Formats the eccentricity as 7 digits with the leading decimal point assumed.
+func formatEccentricity(ecc float64) (string, error) {
+	s := fmt.Sprintf("%.7f", ecc)
+	if ecc < 0 || !strings.HasPrefix(s, "0.") {
+		return "", fmt.Errorf("cannot encode eccentricity: %g", ecc)
+	}
+
+	return s[2:], nil
+}
+
+// Returns the sign of the number in TLE notation: a minus or a space.
+func signChar(f float64) string {
+	if f < 0 {
+		return "-"
+	}
+	return " "
+}
+
+// Returns the angle in the range <0;360).
+func normalizeAngle(a float64) float64 {
+	a = math.Mod(a, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a
+}
+
+// Returns the angle rounded to 4 decimal places, as it is written in TLE,
+// in the range <0;360). The angles rounded up to 360 are written as 0.
+func roundAngle(a float64) float64 {
+	a = normalizeAngle(math.Round(a*1e4) / 1e4)
+
+	// Negative zero would be written with the sign
+	return math.Abs(a)
+}
+
+// Reverses ExpandClass function.
+func shortenClass(class string) string {
+	switch class {
+	case "Classified":
+		return "C"
+	case "Secret":
+		return "S"
+	default:
+		return "U"
+	}
+}
@@ -0,0 +1,127 @@
+package tle
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Tests EncodeTLE function. Encoding of the parsed set must restore
+// the original lines, including the checksums.
+func TestEncodeTLE(t *testing.T) {
+	cases := []Match{
+		*testISS(),
+		{
+			Title: "SWISSCUBE",
+			Line1: "1 35932U 09051B   22013.55765441  .00000268  00000+0  71136-4 0  9999",
+			Line2: "2 35932  98.5837 225.0161 0007892 155.7494 204.4076 14.56655971653547",
+		},
+		{
+			Title: "MOLNIYA 2-14",
+			Line1: "1 08195U 75081A   06176.33215444  .00000099  00000+0  11873-3 0   812",
+			Line2: "2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656",
+		},
+	}
+
+	for _, m := range cases {
+		tle, err := ParseMatch(&m)
+		if err != nil {
+			t.Fatalf("%s: %v", m.Title, err)
+		}
+
+		out, err := EncodeTLE(tle)
+		if err != nil {
+			t.Fatalf("%s: %v", m.Title, err)
+		}
+
+		if out.Title != m.Title || out.Line1 != m.Line1 || out.Line2 != m.Line2 {
+			t.Fatalf("%s: encoded set differs:\n%s\n%s\n%s\n%s", m.Title, out.Line1, m.Line1, out.Line2, m.Line2)
+		}
+
+		if _, err := ParseMatch(out); err != nil {
+			t.Fatalf("%s: encoded set rejected: %v", m.Title, err)
+		}
+	}
+}
+
+// Tests EncodeTLE function with the catalog number that cannot be decoded.
+// The set must be rejected instead of being encoded with number 00000,
+// while a blank number must still be encoded as 00000.
+func TestEncodeTLECatNum(t *testing.T) {
+	tle, err := ParseMatch(testISS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tle.L1.CatNum = "2S544"
+	if _, err := EncodeTLE(tle); err == nil {
+		t.Fatalf("%q: error expected", tle.L1.CatNum)
+	}
+
+	tle.L1.CatNum = ""
+	out, err := EncodeTLE(tle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.Line1, "1 00000") || !strings.HasPrefix(out.Line2, "2 00000") {
+		t.Fatalf("Blank catalog number encoded as %q, %q", out.Line1[:7], out.Line2[:7])
+	}
+}
+
+// Tests EncodeTLE function with the angles close to the ends of the range.
+// The angles rounded up to 360 degrees must be written as 0, and the set
+// must remain valid.
+func TestEncodeTLEAngles(t *testing.T) {
+	tle, err := ParseMatch(testISS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tle.L2.LAN, tle.L2.AgP, tle.L2.MnA = 359.99996, -0.00001, 720.00004
+
+	out, err := EncodeTLE(tle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if angles := out.Line2[17:25] + out.Line2[33:51]; angles != "  0.0000   0.0000   0.0000" {
+		t.Fatalf("Angles encoded as %q", angles)
+	}
+
+	if _, err := ParseMatch(out); err != nil {
+		t.Fatalf("Encoded set rejected: %v", err)
+	}
+}
+
+// Tests formatExponent function. The numbers must be rounded to 5 digits
+// of mantissa and restored by NormalizeFloat within the rounding error.
+func TestFormatExponent(t *testing.T) {
+	cases := map[float64]string{
+		0:               " 00000+0",
+		-0.20061e-4:     "-20061-4",
+		0.71136e-4:      " 71136-4",
+		0.000011873:     " 11873-4",
+		0.5:             " 50000+0",
+		1.5:             " 15000+1",
+		0.999999999:     " 10000+1",
+		-0.123456789e-7: "-12346-7",
+		1e-12:           " 00000+0",
+	}
+
+	for k, v := range cases {
+		s, err := formatExponent(k, "test")
+		if err != nil || s != v {
+			t.Fatalf("%g: expected %q, got %q, %v", k, v, s, err)
+		}
+
+		f, err := strconv.ParseFloat(NormalizeFloat(strings.TrimSpace(s)), 64)
+		if err != nil || math.Abs(f-k) > math.Abs(k)*1e-5+1e-10 {
+			t.Fatalf("%g: %q restored as %g, %v", k, s, f, err)
+		}
+	}
+
+	if _, err := formatExponent(1e10, "test"); err == nil {
+		t.Fatal("Error expected for 1e10")
+	}
+}